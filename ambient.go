@@ -0,0 +1,144 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// LuxPoint is one point on an ambient light curve, mapping a reading from the light sensor (in lux)
+// to a backlight brightness (0.0 - 1.0)
+type LuxPoint struct {
+	Lux        float64
+	Brightness float64
+}
+
+// DefaultLuxCurve is the curve used by FollowAmbientLight if no curve is provided. It keeps the
+// backlight dim in a dark room and at full brightness in daylight
+var DefaultLuxCurve = []LuxPoint{
+	{Lux: 0, Brightness: 0.05},
+	{Lux: 10, Brightness: 0.2},
+	{Lux: 100, Brightness: 0.5},
+	{Lux: 1000, Brightness: 1},
+}
+
+// AmbientLight adjusts the backlight brightness of a Device according to readings from an ambient
+// light sensor. Use func FollowAmbientLight to get a new struct
+type AmbientLight struct {
+	dev      *Device
+	read     func() (float64, error)
+	curve    []LuxPoint
+	interval time.Duration
+	mu       sync.Mutex
+	step     float64
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// FollowAmbientLight starts adjusting the backlight brightness to the ambient light. Arguments are
+//
+//	read:		Function returning the current reading of the light sensor, in lux
+//	interval:	How often the sensor is read
+//	curve:		Points mapping lux to brightness, values in between are interpolated. DefaultLuxCurve is used if empty
+//
+// The brightness is changed gradually, see SetSmoothing. Call Stop on the returned struct to stop
+// following the ambient light
+func (l *Device) FollowAmbientLight(read func() (float64, error), interval time.Duration, curve ...LuxPoint) (*AmbientLight, error) {
+	if read == nil || interval <= 0 {
+		return nil, errors.New("A read function and a positive interval must be provided")
+	}
+	if !l.hasPwmBacklight() {
+		return nil, errors.New("Brightness requires the L pin to be a hardware PWM pin")
+	}
+	if len(curve) == 0 {
+		curve = DefaultLuxCurve
+	}
+	c := make([]LuxPoint, len(curve))
+	copy(c, curve)
+	sort.Slice(c, func(i, j int) bool { return c[i].Lux < c[j].Lux })
+	a := &AmbientLight{
+		dev:      l,
+		read:     read,
+		curve:    c,
+		interval: interval,
+		step:     0.1,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go a.run()
+	return a, nil
+}
+
+// SetSmoothing sets the largest change in brightness (0.0 - 1.0) made per interval. A value of 1
+// disables smoothing, i.e. the brightness jumps directly to the value given by the curve. Default is 0.1
+func (a *AmbientLight) SetSmoothing(step float64) {
+	if step <= 0 || step > 1 {
+		step = 1
+	}
+	a.mu.Lock()
+	a.step = step
+	a.mu.Unlock()
+}
+
+// Stop stops following the ambient light, leaving the backlight at its current brightness
+func (a *AmbientLight) Stop() {
+	a.stopOnce.Do(func() { close(a.stop) })
+	<-a.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (a *AmbientLight) Done() <-chan struct{} {
+	return a.done
+}
+
+// run reads the sensor every interval and moves the brightness towards the target given by the curve
+func (a *AmbientLight) run() {
+	defer close(a.done)
+	t := time.NewTicker(a.interval)
+	defer t.Stop()
+	for {
+		if lux, err := a.read(); err == nil {
+			a.adjust(a.target(lux))
+		}
+		select {
+		case <-a.stop:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// adjust moves the brightness of the device at most one step towards target
+func (a *AmbientLight) adjust(target float64) {
+	a.mu.Lock()
+	step := a.step
+	a.mu.Unlock()
+	cur := a.dev.Brightness()
+	switch {
+	case target > cur+step:
+		target = cur + step
+	case target < cur-step:
+		target = cur - step
+	}
+	a.dev.SetBrightness(target)
+}
+
+// target returns the brightness for the lux reading, interpolated linearly between the points of the curve
+func (a *AmbientLight) target(lux float64) float64 {
+	c := a.curve
+	if lux <= c[0].Lux {
+		return c[0].Brightness
+	}
+	for i := 1; i < len(c); i++ {
+		if lux <= c[i].Lux {
+			f := (lux - c[i-1].Lux) / (c[i].Lux - c[i-1].Lux)
+			return c[i-1].Brightness + f*(c[i].Brightness-c[i-1].Brightness)
+		}
+	}
+	return c[len(c)-1].Brightness
+}
@@ -0,0 +1,145 @@
+package st7066u
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// animationFrame is how long a frame is shown if neither the frame nor the animation gives a duration
+const animationFrame = time.Millisecond * 100
+
+// AnimationFrame is one frame of an Animation
+type AnimationFrame struct {
+	Lines    []string       // Text of the frame, one line per row of the region from the top, nil to leave the text as is
+	Glyphs   map[rune]Glyph // Glyphs registered for the frame, see RegisterGlyph, changing them also where shown
+	Duration time.Duration  // How long the frame is shown, the Duration of the animation if 0
+}
+
+// Animation is a sequence of frames shown in a region of the display, or the full display, e.g. a
+// spinner or a small character walking across the display. Frames may change the text, and the
+// glyphs of registered runes; as a glyph changed is changed where it is shown, a few user-defined
+// characters can be animated without writing any text at all. Play an animation with func Play
+type Animation struct {
+	Row, Col uint8 // Top left of the region of the frames
+	Frames   []AnimationFrame
+	Duration time.Duration // How long each frame is shown, unless given by the frame, 100 ms if 0
+	Loop     bool          // The animation starts over after the last frame, until stopped
+}
+
+// Player plays an Animation, see func Play
+type Player struct {
+	mu       sync.Mutex
+	frame    int // The frame shown
+	jump     int // The frame to show next, set by SetState, -1 if none
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Play plays the animation in a goroutine, writing only the characters that changed by each frame.
+// Call Stop on the returned struct to stop the animation, leaving the frame shown, or Wait to wait
+// for an animation that doesn't loop to end
+func (l *Device) Play(a Animation) *Player {
+	p := &Player{
+		jump: -1,
+		wake: make(chan struct{}, 1),
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+		if len(a.Frames) == 0 {
+			return
+		}
+		timer := time.NewTimer(time.Hour)
+		defer timer.Stop()
+		for i := 0; ; i++ {
+			if i == len(a.Frames) {
+				if !a.Loop {
+					return
+				}
+				i = 0
+			}
+			p.mu.Lock()
+			if p.jump >= 0 && p.jump < len(a.Frames) {
+				i = p.jump
+			}
+			p.frame, p.jump = i, -1
+			p.mu.Unlock()
+			fr := a.Frames[i]
+			for r, g := range fr.Glyphs {
+				l.RegisterGlyph(r, g)
+			}
+			if fr.Lines != nil {
+				l.Update(func(f *Frame) {
+					for r, line := range fr.Lines {
+						f.printField(a.Row+uint8(r), a.Col, uint8(len([]rune(line))), line, false)
+					}
+				})
+			}
+			d := fr.Duration
+			if d <= 0 {
+				d = a.Duration
+			}
+			if d <= 0 {
+				d = animationFrame
+			}
+			resetTimer(timer, d)
+			select {
+			case <-p.stop:
+				return
+			case <-p.wake:
+				i--
+			case <-timer.C:
+			}
+		}
+	}()
+	return p
+}
+
+// PlayContext plays the animation as Play, stopping it when ctx is done, see func StopOnCancel
+func (l *Device) PlayContext(ctx context.Context, a Animation) *Player {
+	p := l.Play(a)
+	StopOnCancel(ctx, p)
+	return p
+}
+
+// State returns the index of the frame shown, see type Scene
+func (p *Player) State() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return strconv.Itoa(p.frame)
+}
+
+// SetState shows the frame of a state returned by State at once, going on with the animation from
+// there. Frames beyond the frames of the animation are ignored
+func (p *Player) SetState(state string) error {
+	frame, err := strconv.Atoi(state)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	p.jump = frame
+	p.mu.Unlock()
+	wakeUp(p.wake)
+	return nil
+}
+
+// Stop stops the animation and waits for it to end, leaving the frame shown
+func (p *Player) Stop() {
+	p.stopOnce.Do(func() { close(p.stop) })
+	<-p.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (p *Player) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait waits for the animation to end
+func (p *Player) Wait() {
+	<-p.done
+}
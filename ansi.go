@@ -0,0 +1,69 @@
+package st7066u
+
+// States of ansiFilter
+const (
+	ansiText = iota
+	ansiEsc  // After ESC
+	ansiCSI  // In a control sequence, ESC [ ... final byte
+	ansiOSC  // In an operating system command, ESC ] ... BEL or ESC \
+	ansiOSCEsc
+)
+
+// ansiFilter removes ANSI escape sequences (colors, cursor movement etc.) from a stream of runes.
+// The state is kept between runes, so sequences may be split between writes
+type ansiFilter struct {
+	state int
+}
+
+// StripANSI returns the text with all ANSI escape sequences removed, e.g. the colors in the output
+// of command line tools, which would otherwise be printed as garbage on the display
+func StripANSI(text string) string {
+	var f ansiFilter
+	out := make([]rune, 0, len(text))
+	for _, c := range text {
+		if f.keep(c) {
+			out = append(out, c)
+		}
+	}
+	return string(out)
+}
+
+// keep reports if the rune is text to be kept, i.e. not part of an escape sequence
+func (f *ansiFilter) keep(c rune) bool {
+	switch f.state {
+	case ansiEsc:
+		switch c {
+		case '[':
+			f.state = ansiCSI
+		case ']':
+			f.state = ansiOSC
+		default:
+			f.state = ansiText // Two character sequence, e.g. ESC c
+		}
+		return false
+	case ansiCSI:
+		if c >= 0x40 && c <= 0x7e {
+			f.state = ansiText
+		}
+		return false
+	case ansiOSC:
+		switch c {
+		case 0x07:
+			f.state = ansiText
+		case 0x1b:
+			f.state = ansiOSCEsc
+		}
+		return false
+	case ansiOSCEsc:
+		f.state = ansiText
+		if c != '\\' {
+			f.state = ansiOSC
+		}
+		return false
+	}
+	if c == 0x1b {
+		f.state = ansiEsc
+		return false
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package st7066u
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"plain", "Hello", "Hello"},
+		{"color", "\x1b[1;31mError\x1b[0m: disk", "Error: disk"},
+		{"cursor", "\x1b[2J\x1b[HTop", "Top"},
+		{"two characters", "\x1bcReset", "Reset"},
+		{"title, BEL", "\x1b]0;title\x07Text", "Text"},
+		{"title, ST", "\x1b]0;title\x1b\\Text", "Text"},
+		{"unterminated", "Text\x1b[31", "Text"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(tt.text); got != tt.want {
+				t.Errorf("Got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerminalANSI(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		raw    bool
+		want   []string
+	}{
+		{"whole", []string{"\x1b[32mOK\x1b[0m\n"}, false, []string{"OK"}},
+		{"split after ESC", []string{"\x1b", "[32mOK\n"}, false, []string{"OK"}},
+		{"split in parameters", []string{"\x1b[3", "2;1", "mOK\n"}, false, []string{"OK"}},
+		{"split before final byte", []string{"A\x1b[0", "mB\n"}, false, []string{"AB"}},
+		{"split OSC", []string{"\x1b]0;ti", "tle\x1b", "\\Text\n"}, false, []string{"Text"}},
+		{"one byte a write", []string{"\x1b", "[", "1", "m", "X", "\n"}, false, []string{"X"}},
+		{"raw", []string{"\x1b", "[1mX\n"}, true, []string{"\x1b[1mX"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := newTestDevice(t, "2004", BITMODE8)
+			term := d.Terminal()
+			term.SetStripANSI(!tt.raw)
+			for _, w := range tt.writes {
+				term.Write([]byte(w))
+			}
+			if got := term.Lines(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package st7066u
+
+// SetAsync turns the async mode on or off. In async mode, calls writing to the display (Print,
+// SetCursor, LedOn etc.) return immediately, and the operations are written in order by a single
+// worker goroutine. queueSize is the nr of operations that can be pending before the calls block.
+// A queueSize of 0 turns the async mode off, after writing any pending operations
+func (l *Device) SetAsync(queueSize int) {
+	l.stopWorker()
+	if queueSize <= 0 {
+		return
+	}
+	l.qmu.Lock()
+	l.queue = make(chan func(), queueSize)
+	l.worker = make(chan struct{})
+	go l.work(l.queue, l.worker)
+	l.qmu.Unlock()
+}
+
+// Flush blocks until all operations queued in async mode, and any changes held back by the rate
+// limit (see SetRateLimit), have been written to the display
+func (l *Device) Flush() {
+	l.doWait(l.flushHeld)
+}
+
+// do runs the operation while holding the lock of the device, or queues it in async mode
+func (l *Device) do(op func()) {
+	l.qmu.RLock()
+	if l.queue != nil {
+		l.queue <- op
+		l.qmu.RUnlock()
+		return
+	}
+	l.qmu.RUnlock()
+	l.mu.Lock()
+	op()
+	l.unlockSync()
+}
+
+// lockSync locks the device and returns true unless in async mode, for the hot paths to run their
+// operation at once instead of passing it to do, which saves the allocation of a closure. The caller
+// must call unlockSync when done. false is returned in async mode, without locking
+func (l *Device) lockSync() bool {
+	l.qmu.RLock()
+	async := l.queue != nil
+	l.qmu.RUnlock()
+	if async {
+		return false
+	}
+	l.mu.Lock()
+	return true
+}
+
+// unlockSync unlocks the device after an operation, and passes any changes to the mirror function
+func (l *Device) unlockSync() {
+	notify := l.mirrorChanges()
+	l.mu.Unlock()
+	notify()
+}
+
+// doWait runs the operation as do does, but also waits for it to be done in async mode, as needed
+// by operations returning a result
+func (l *Device) doWait(op func()) {
+	done := make(chan struct{})
+	l.do(func() {
+		op()
+		close(done)
+	})
+	<-done
+}
+
+// work runs the queued operations until the queue is closed
+func (l *Device) work(queue chan func(), done chan struct{}) {
+	defer close(done)
+	for op := range queue {
+		l.mu.Lock()
+		op()
+		notify := l.mirrorChanges()
+		l.mu.Unlock()
+		notify()
+	}
+}
+
+// stopWorker closes the queue of the async mode, if any, and waits for the pending operations to
+// be written
+func (l *Device) stopWorker() {
+	l.qmu.Lock()
+	q, done := l.queue, l.worker
+	l.queue, l.worker = nil, nil
+	l.qmu.Unlock()
+	if q == nil {
+		return
+	}
+	close(q)
+	<-done
+}
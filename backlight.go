@@ -0,0 +1,74 @@
+package st7066u
+
+import "time"
+
+const (
+	pwmCycle = 256
+	pwmFreq  = pwmCycle * 400 // ~400 Hz on the pin, well above visible flicker
+)
+
+// Brightness returns the current brightness level of the backlight, from 0.0 to 1.0
+func (l *Device) Brightness() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.brightness
+}
+
+// SetBacklightActiveLow sets if the backlight is on when the L pin is low, e.g. when switched by a PNP
+// transistor, instead of high, the default. With SetBrightness, the duty cycle is inverted. It also
+// applies to the L pin of an I2C backpack written to with NewFromPins. The L pin is set accordingly
+// at once, so call it right after opening the display to keep the backlight from flashing
+func (l *Device) SetBacklightActiveLow(on bool) {
+	l.do(func() {
+		l.backlightLow = on
+		l.applyBacklight()
+	})
+}
+
+// applyBacklight sets the L pin according to the current on/off state and, once SetBrightness has
+// been used, the brightness. The pins of an RGB backlight, and the backlight of the tee targets, are
+// set as well
+func (l *Device) applyBacklight() {
+	l.applyRGB()
+	for _, t := range l.tees {
+		t.mu.Lock()
+		t.ledOn, t.brightness = l.backlightOn(), l.brightness
+		t.applyBacklight()
+		t.mu.Unlock()
+	}
+	if !l.pwmOn {
+		if l.backlightOn() != l.backlightLow {
+			l.pinL.High()
+		} else {
+			l.pinL.Low()
+		}
+		return
+	}
+	var duty uint32
+	if l.backlightOn() {
+		duty = uint32(l.brightness*pwmCycle + 0.5)
+	}
+	if l.backlightLow {
+		duty = pwmCycle - duty
+	}
+	l.setPwmDuty(duty)
+}
+
+// blinkBacklight turns the backlight off and on again the nr of times, once per period, leaving it
+// as it was. A nr of times of 0 or less blinks until stop is closed, which also ends the blinking
+// early
+func (l *Device) blinkBacklight(times int, period time.Duration, stop <-chan struct{}) {
+	var on bool
+	l.doWait(func() { on = l.ledOn })
+	defer l.LedOn(on)
+	for i := 0; times <= 0 || i < times; i++ {
+		for _, state := range []bool{!on, on} {
+			l.LedOn(state)
+			select {
+			case <-stop:
+				return
+			case <-time.After(period / 2):
+			}
+		}
+	}
+}
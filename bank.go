@@ -0,0 +1,68 @@
+//go:build linux && !tinygo
+// +build linux,!tinygo
+
+package st7066u
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/stianeikeland/go-rpio"
+)
+
+// Offsets (in bytes) of the GPIO set and clear registers for pins 0-31
+const (
+	regGPSET0 = 0x1c
+	regGPCLR0 = 0x28
+)
+
+// gpioBank is a memory mapping of the GPIO registers, used to set all data pins with one write to
+// the set register and one to the clear register, instead of one High()/Low() per pin. This reduces
+// the setup time per byte, and the skew between the data pins
+type gpioBank struct {
+	mem   []byte
+	masks []uint32 // Bit of each data pin in the set and clear registers
+}
+
+// openBank maps the GPIO registers through /dev/gpiomem. An error is returned if the pins are not
+// all in the first bank (pins 0-31), or if the registers can't be mapped
+func openBank(pins []rpio.Pin) (*gpioBank, error) {
+	b := &gpioBank{masks: make([]uint32, len(pins))}
+	for i, p := range pins {
+		if p > 31 {
+			return nil, errors.New("Data pins are not in the same GPIO bank")
+		}
+		b.masks[i] = 1 << p
+	}
+	f, err := os.OpenFile("/dev/gpiomem", os.O_RDWR|os.O_SYNC, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	b.mem, err = syscall.Mmap(int(f.Fd()), 0, 4096, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// write sets the data pins to the lowest bits of data
+func (b *gpioBank) write(data uint8) {
+	var set, clr uint32
+	for i, m := range b.masks {
+		if data&(1<<i) != 0 {
+			set |= m
+		} else {
+			clr |= m
+		}
+	}
+	*(*uint32)(unsafe.Pointer(&b.mem[regGPSET0])) = set
+	*(*uint32)(unsafe.Pointer(&b.mem[regGPCLR0])) = clr
+}
+
+// close unmaps the GPIO registers
+func (b *gpioBank) close() {
+	syscall.Munmap(b.mem)
+}
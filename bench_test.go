@@ -0,0 +1,83 @@
+package st7066u
+
+import (
+	"strings"
+	"testing"
+)
+
+var (
+	benchTexts   = []string{"Temp 21.5C  ON  ", "Temp 21.6C  OFF "}
+	benchScreens = [][]string{
+		{strings.Repeat("A", 16), strings.Repeat("B", 16)},
+		{strings.Repeat("C", 16), strings.Repeat("D", 16)},
+	}
+)
+
+// redraw changes every character of the display, as one update
+func redraw(d *Device, i int) {
+	d.Update(func(f *Frame) {
+		for r, line := range benchScreens[i%2] {
+			f.PrintAt(uint8(r), 0, line)
+		}
+	})
+}
+
+// TestNoAllocs fails if the hot paths allocate, which would put the garbage collector to work on
+// small boards updating the display often
+func TestNoAllocs(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(d *Device, i int)
+	}{
+		{"Print", func(d *Device, i int) { d.Print(benchTexts[i%2]) }},
+		{"PrintAt", func(d *Device, i int) { d.PrintAt(1, 0, benchTexts[i%2]) }},
+		{"PrintRune", func(d *Device, i int) { d.PrintRune('ä') }},
+		{"SetCursor", func(d *Device, i int) { d.SetCursor(1, uint8(i%16)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, _ := newTestDevice(t, "1602", BITMODE4)
+			i := 0
+			if n := testing.AllocsPerRun(100, func() {
+				tt.fn(d, i)
+				i++
+			}); n > 0 {
+				t.Errorf("%.1f allocations per run, want none", n)
+			}
+		})
+	}
+}
+
+// benchmark benchmarks the operation on a simulated 16x2 display in the mode, without the waits of
+// the controller, which are most of the time of an operation on a real display, so allocations are
+// what to look for
+func benchmark(b *testing.B, mode uint8, fn func(d *Device, i int)) {
+	d, _ := newTestDevice(b, "1602", mode)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fn(d, i)
+	}
+}
+
+func BenchmarkPrint(b *testing.B) {
+	benchmark(b, BITMODE4, func(d *Device, i int) { d.Print(benchTexts[i%2]) })
+}
+
+func BenchmarkPrintAt(b *testing.B) {
+	benchmark(b, BITMODE4, func(d *Device, i int) { d.PrintAt(1, 0, benchTexts[i%2]) })
+}
+
+func BenchmarkUpdate(b *testing.B) {
+	benchmark(b, BITMODE4, func(d *Device, i int) {
+		d.Update(func(f *Frame) { f.PrintAt(0, 0, benchTexts[i%2]) })
+	})
+}
+
+func BenchmarkRedraw4(b *testing.B) {
+	benchmark(b, BITMODE4, redraw)
+}
+
+func BenchmarkRedraw8(b *testing.B) {
+	benchmark(b, BITMODE8, redraw)
+}
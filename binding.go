@@ -0,0 +1,97 @@
+package st7066u
+
+import (
+	"sync"
+	"time"
+)
+
+// Binding is a field of the display bound to a function returning its text, see func BindField
+type Binding struct {
+	l               *Device
+	row, col, width uint8
+	value           func() string
+	refresh         time.Duration
+	mu              sync.Mutex
+	last            string
+	drawn           bool
+	wake            chan struct{}
+	stop            chan struct{}
+	done            chan struct{}
+	stopOnce        sync.Once
+}
+
+// BindField binds a field of width cells at the row and col to the function, which is evaluated
+// every refresh interval by a goroutine. The field is written only when the text returned has
+// changed, left-aligned and truncated to the width, so dashboards need no update loops of their own.
+// A refresh interval of 0 evaluates the function only when Refresh is called on the returned struct.
+// Call Stop on the returned struct to unbind the field, leaving its text as is
+func (l *Device) BindField(row, col, width uint8, value func() string, refresh time.Duration) *Binding {
+	b := &Binding{
+		l:       l,
+		row:     row,
+		col:     col,
+		width:   width,
+		value:   value,
+		refresh: refresh,
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// Refresh evaluates the function of the field now, writing the field if the text has changed. The
+// field is also written if it has been overwritten by something else
+func (b *Binding) Refresh() {
+	b.mu.Lock()
+	b.drawn = false
+	b.mu.Unlock()
+	select {
+	case b.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Stop unbinds the field, leaving its text as is
+func (b *Binding) Stop() {
+	b.stopOnce.Do(func() { close(b.stop) })
+	<-b.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (b *Binding) Done() <-chan struct{} {
+	return b.done
+}
+
+// run evaluates the function every refresh interval, or when woken, until stopped
+func (b *Binding) run() {
+	defer close(b.done)
+	var tick <-chan time.Time
+	if b.refresh > 0 {
+		t := time.NewTicker(b.refresh)
+		defer t.Stop()
+		tick = t.C
+	}
+	for {
+		b.update()
+		select {
+		case <-b.stop:
+			return
+		case <-b.wake:
+		case <-tick:
+		}
+	}
+}
+
+// update evaluates the function, and writes the field if the text has changed
+func (b *Binding) update() {
+	text := b.value()
+	b.mu.Lock()
+	changed := !b.drawn || text != b.last
+	b.last, b.drawn = text, true
+	b.mu.Unlock()
+	if changed {
+		b.l.Update(func(f *Frame) { f.printField(b.row, b.col, b.width, text, false) })
+	}
+}
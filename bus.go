@@ -0,0 +1,17 @@
+package st7066u
+
+import (
+	"sync"
+)
+
+// Bus is a set of data pins and an RS pin shared by several displays, each with its own E pin. This
+// is a common wiring for projects with more than one display, as only one extra GPIO pin is needed
+// per display. Writes from the displays on the bus are serialized by the Bus. Use func NewBus to
+// get a new struct
+type Bus struct {
+	mu    sync.Mutex // Held while writing one byte or nibble to any of the displays
+	mode  uint8
+	pinRS Pin
+	pinDs []Pin
+	bank  *gpioBank
+}
@@ -0,0 +1,71 @@
+//go:build linux && !tinygo
+// +build linux,!tinygo
+
+package st7066u
+
+import (
+	"github.com/stianeikeland/go-rpio"
+)
+
+// NewBus returns a Bus for displays sharing the RS and data pins. mode and pins are as for New. Use
+// NewDevice to add the displays, and Close to close the bus when all displays are closed
+func NewBus(mode uint8, pinRS rpio.Pin, pins ...rpio.Pin) (*Bus, error) {
+	if err := validatePinMode(mode, len(pins)); err != nil {
+		return nil, err
+	}
+	if err := rpio.Open(); err != nil {
+		return nil, err
+	}
+	b := &Bus{
+		mode:  mode,
+		pinRS: pinRS,
+		pinDs: make([]Pin, len(pins)),
+	}
+	for i, p := range pins {
+		b.pinDs[i] = p
+	}
+	if err := claimPins(b, append([]Pin{pinRS}, b.pinDs...)...); err != nil {
+		rpio.Close()
+		return nil, err
+	}
+	if bank, err := openBank(pins); err == nil {
+		b.bank = bank
+	}
+	return b, nil
+}
+
+// NewDevice returns a Device for a display on the bus, with its own E pin and L pin (or NoPin).
+// nrOfRows, nrOfCols and charSym are as for New
+func (b *Bus) NewDevice(nrOfRows, nrOfCols uint8, charSym uint8, pinE, pinL rpio.Pin) (*Device, error) {
+	if err := validateSymm(nrOfRows, nrOfCols, charSym); err != nil {
+		return nil, err
+	}
+	claim := []Pin{pinE}
+	var l Pin = noPin{}
+	if pinL != NoPin {
+		l = pinL
+		claim = append(claim, pinL)
+	}
+	ds := append([]Pin(nil), b.pinDs...)
+	g := newDevice(newGeometry(nrOfRows, nrOfCols), charSym, b.pinRS, pinE, l, ds)
+	g.bus = b
+	g.bank = b.bank
+	if err := claimPins(g, claim...); err != nil {
+		return nil, err
+	}
+	g.setDefaultMasks()
+	g.start()
+	return g, nil
+}
+
+// Close closes the bus. Close all displays on the bus first
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	releasePins(b)
+	if b.bank != nil {
+		b.bank.close()
+		b.bank = nil
+	}
+	rpio.Close()
+}
@@ -0,0 +1,28 @@
+package st7066u
+
+// Capabilities describes the features supported by a Device, as wired and configured. Use func
+// Capabilities to get the capabilities of a Device
+type Capabilities struct {
+	ReadBack         bool // DDRAM and CGRAM can be read back from the display
+	PWMBacklight     bool // Brightness of the backlight can be set, see SetBrightness
+	RGBBacklight     bool // Color of the backlight can be set, see SetBacklightColor
+	Contrast         bool // Contrast can be set
+	SecondController bool // The display has a second controller, e.g. 40x4 displays
+}
+
+// Capabilities returns the features supported by the device, so that code can feature-detect
+// instead of assuming a certain display or wiring
+func (l *Device) Capabilities() Capabilities {
+	return Capabilities{
+		ReadBack:         l.pinRW != nil,
+		PWMBacklight:     l.hasPwmBacklight(),
+		RGBBacklight:     l.rgb != nil,
+		Contrast:         l.contrastCh != nil || l.contrastVariant(),
+		SecondController: l.geo.controllers() > 1,
+	}
+}
+
+// Size returns the nr of rows and columns of the display
+func (l *Device) Size() (rows, cols uint8) {
+	return l.rows, l.cols
+}
@@ -1,6 +1,9 @@
 package st7066u
 
-import "unicode/utf8"
+import (
+	"sync"
+	"unicode/utf8"
+)
 
 func strToSt70660b(inp string) (ut []byte) {
 	ut = make([]byte, utf8.RuneCountInString(inp))
@@ -19,7 +22,34 @@ func runeToSt70660b(inp rune) byte {
 	return 0x3f // Question mark
 }
 
+// st70660bToRune returns the rune of the ROM code, the lowest rune in case of several candidates.
+// Codes of the user-defined characters are returned as is
+func st70660bToRune(inp byte) rune {
+	if inp < 8 {
+		return rune(inp)
+	}
+	runeMapOnce.Do(func() {
+		runeMap = make(map[byte]rune)
+		for r, b := range charMap {
+			if c, ok := runeMap[b]; !ok || r < c {
+				runeMap[b] = r
+			}
+		}
+	})
+	if r, ok := runeMap[inp]; ok {
+		return r
+	}
+	return '?'
+}
+
+// runeMap is the reverse of charMap, built on first use
+var (
+	runeMap     map[byte]rune
+	runeMapOnce sync.Once
+)
+
 var charMap = map[rune]byte{
+	' ':      0x20,
 	'!':      0x21,
 	'"':      0x22,
 	'#':      0x23,
@@ -0,0 +1,146 @@
+package st7066u
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Clock is a self-updating clock or date on the display, see func ShowClock
+type Clock struct {
+	l        *Device
+	row, col uint8
+	layout   string
+	width    uint8 // Width of the widest text written, to clear what is left of a wider text
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// ShowClock shows the local time at the row and col, formatted by the strftime-like layout, e.g.
+// "%H:%M:%S" or "%a %d %b %H:%M". The clock is updated by a goroutine exactly on the second boundary
+// if the layout shows seconds, and on the minute boundary otherwise, and only the characters that
+// changed are written. The conversions are
+//
+//	%H	Hour, 00 - 23		%I	Hour, 01 - 12		%p	AM or PM
+//	%M	Minute, 00 - 59		%S	Second, 00 - 59		%T	Same as %H:%M:%S
+//	%R	Same as %H:%M		%d	Day, 01 - 31		%e	Day, 1 - 31, padded with a space
+//	%m	Month, 01 - 12		%y	Year, 00 - 99		%Y	Year, e.g. 2024
+//	%a	Weekday, e.g. Mon	%A	Weekday, e.g. Monday	%b	Month, e.g. Jan
+//	%B	Month, e.g. January	%j	Day of the year, 001 - 366	%F	Same as %Y-%m-%d
+//	%%	A %
+//
+// Call Stop on the returned struct to stop updating the clock, leaving it as is
+func (l *Device) ShowClock(row, col uint8, layout string) *Clock {
+	c := &Clock{
+		l:      l,
+		row:    row,
+		col:    col,
+		layout: layout,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go c.run()
+	return c
+}
+
+// Stop stops updating the clock, leaving it as is
+func (c *Clock) Stop() {
+	c.stopOnce.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (c *Clock) Done() <-chan struct{} {
+	return c.done
+}
+
+// run writes the clock on every second or minute boundary, until stopped
+func (c *Clock) run() {
+	defer close(c.done)
+	unit := time.Minute
+	if strings.Contains(c.layout, "%S") || strings.Contains(c.layout, "%T") {
+		unit = time.Second
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-timer.C:
+		}
+		now := time.Now()
+		c.draw(now)
+		// Truncate rounds the absolute time, whose minutes are those of the local time zone as well
+		timer.Reset(now.Truncate(unit).Add(unit).Sub(time.Now()))
+	}
+}
+
+// draw writes the clock showing the time t
+func (c *Clock) draw(t time.Time) {
+	text := strftime(t, c.layout)
+	if n := len([]rune(text)); n > int(c.width) {
+		c.width = uint8(n)
+	}
+	row, col, width := c.row, c.col, c.width
+	c.l.Update(func(f *Frame) { f.printField(row, col, width, text, false) })
+}
+
+// strftime formats the time t by the strftime-like layout, see func ShowClock
+func strftime(t time.Time, layout string) string {
+	var b strings.Builder
+	r := []rune(layout)
+	for i := 0; i < len(r); i++ {
+		if r[i] != '%' || i == len(r)-1 {
+			b.WriteRune(r[i])
+			continue
+		}
+		i++
+		switch r[i] {
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'I':
+			fmt.Fprintf(&b, "%02d", (t.Hour()+11)%12+1)
+		case 'p':
+			b.WriteString(t.Format("PM"))
+		case 'M':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case 'S':
+			fmt.Fprintf(&b, "%02d", t.Second())
+		case 'T':
+			b.WriteString(t.Format("15:04:05"))
+		case 'R':
+			b.WriteString(t.Format("15:04"))
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'e':
+			fmt.Fprintf(&b, "%2d", t.Day())
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case 'y':
+			fmt.Fprintf(&b, "%02d", t.Year()%100)
+		case 'Y':
+			fmt.Fprintf(&b, "%d", t.Year())
+		case 'a':
+			b.WriteString(t.Format("Mon"))
+		case 'A':
+			b.WriteString(t.Format("Monday"))
+		case 'b':
+			b.WriteString(t.Format("Jan"))
+		case 'B':
+			b.WriteString(t.Format("January"))
+		case 'j':
+			fmt.Fprintf(&b, "%03d", t.YearDay())
+		case 'F':
+			b.WriteString(t.Format("2006-01-02"))
+		case '%':
+			b.WriteRune('%')
+		default:
+			b.WriteRune('%')
+			b.WriteRune(r[i])
+		}
+	}
+	return b.String()
+}
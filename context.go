@@ -0,0 +1,27 @@
+package st7066u
+
+import "context"
+
+// Stopper is a helper running in a goroutine until stopped, e.g. a *Player, *Clock, *Countdown,
+// *Effect, *ScreenManager or *Scene, see func StopOnCancel. Helpers of the caller may implement it
+// too, to be stopped the same way
+type Stopper interface {
+	Stop()
+	Done() <-chan struct{} // Closed when the goroutine of the helper has ended
+}
+
+// StopOnCancel stops s when ctx is done, e.g. to stop the helpers of a program promptly when it
+// shuts down, with the context of the program, instead of keeping track of them to stop them one by
+// one. Waiting for ctx ends as s ends, also if stopped otherwise, so nothing is leaked if ctx is
+// never done, e.g.
+//
+//	st7066u.StopOnCancel(ctx, lcd.Play(spinner))
+func StopOnCancel(ctx context.Context, s Stopper) {
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Stop()
+		case <-s.Done():
+		}
+	}()
+}
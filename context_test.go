@@ -0,0 +1,75 @@
+package st7066u
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStopConcurrently(t *testing.T) {
+	d, _ := newTestDevice(t, "1602", BITMODE4)
+	stoppers := map[string]func() Stopper{
+		"Binding":       func() Stopper { return d.BindField(0, 0, 4, func() string { return "x" }, time.Millisecond) },
+		"Clock":         func() Stopper { return d.ShowClock(0, 0, "15:04:05") },
+		"Countdown":     func() Stopper { return d.Countdown(0, 0, time.Minute, nil) },
+		"Effect":        func() Stopper { return d.BlinkBacklight(100, time.Millisecond*10) },
+		"Player":        func() Stopper { return d.Play(Animation{Loop: true, Frames: []AnimationFrame{{}}}) },
+		"Scene":         func() Stopper { return NewScene(NewMemoryStore(), time.Millisecond) },
+		"ScreenManager": func() Stopper { return d.NewScreenManager() },
+		"Stopwatch":     func() Stopper { return d.Stopwatch(0, 0) },
+	}
+	for name, start := range stoppers {
+		s := start()
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s.Stop()
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+// ticker is a Stopper of the caller
+type ticker struct {
+	stop, done chan struct{}
+}
+
+func (tk *ticker) Stop() {
+	close(tk.stop)
+	<-tk.done
+}
+
+func (tk *ticker) Done() <-chan struct{} {
+	return tk.done
+}
+
+func TestStopOnCancel(t *testing.T) {
+	d, _ := newTestDevice(t, "1602", BITMODE4)
+	tk := &ticker{stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(tk.done)
+		<-tk.stop
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	StopOnCancel(ctx, tk)
+	stoppers := map[string]Stopper{
+		"caller":      tk,
+		"PlayContext": d.PlayContext(ctx, Animation{Loop: true, Frames: []AnimationFrame{{}}}),
+	}
+	cancel()
+	for name, s := range stoppers {
+		t.Run(name, func(t *testing.T) {
+			select {
+			case <-s.Done():
+			case <-time.After(time.Second):
+				t.Error("Not stopped when the context was done")
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package st7066u
+
+import (
+	"errors"
+	"os"
+)
+
+// errNoContrastSim is returned when setting a source of the contrast voltage on a simulated display
+var errNoContrastSim = errors.New("Contrast is not simulated")
+
+// contrastChannel is an external source of the contrast voltage on V0, see SetContrastPWM and
+// SetContrastDAC
+type contrastChannel interface {
+	set(level float64) error
+	close()
+}
+
+// dacContrast is an MCP4725 DAC on an I2C bus feeding V0
+type dacContrast struct {
+	f *os.File
+}
+
+// SetContrastDAC sets an MCP4725 DAC, at the address on the I2C bus (e.g. 1 for /dev/i2c-1), feeding
+// V0 of the display as the source of the contrast, see SetContrast. The address of the MCP4725 is
+// 0x60 or 0x61, depending on the A0 pin, for the most common variant. An error is returned if another
+// open device uses the address on the bus
+func (l *Device) SetContrastDAC(bus int, addr uint8) error {
+	if l.backend == "simulator" {
+		return errNoContrastSim
+	}
+	if err := claimI2C(l, bus, addr); err != nil {
+		return err
+	}
+	f, err := openI2C(bus, addr)
+	if err != nil {
+		releaseI2C(l, bus, addr)
+		return err
+	}
+	return l.setContrastChannel(&dacContrast{f: f})
+}
+
+// SetContrast sets the contrast of the display, from 0.0 (lowest) to 1.0 (highest). This requires a
+// source of the contrast voltage, see SetContrastPWM and SetContrastDAC, or a controller variant
+// supporting it, see SetVariant
+func (l *Device) SetContrast(level float64) error {
+	if level < 0 {
+		level = 0
+	}
+	if level > 1 {
+		level = 1
+	}
+	err := errors.New("Contrast requires a contrast pin or DAC, or a controller variant supporting it")
+	l.doWait(func() {
+		switch {
+		case l.contrastCh != nil:
+			l.contrast = level
+			err = l.contrastCh.set(level)
+		case l.contrastVariant():
+			l.contrast = level
+			l.writeContrast()
+			err = nil
+		}
+	})
+	return err
+}
+
+// setContrastChannel replaces the contrast channel, setting the current contrast on the new one
+func (l *Device) setContrastChannel(ch contrastChannel) error {
+	var err error
+	l.doWait(func() {
+		if l.contrastCh != nil {
+			l.contrastCh.close()
+		}
+		l.contrastCh = ch
+		err = ch.set(l.contrast)
+	})
+	return err
+}
+
+// set implements contrastChannel, with a fast mode write of the 12 bit value. The contrast is
+// highest at the lowest voltage
+func (c *dacContrast) set(level float64) error {
+	v := uint16((1-level)*0xfff + 0.5)
+	_, err := c.f.Write([]byte{byte(v >> 8), byte(v)})
+	return err
+}
+
+// close implements contrastChannel
+func (c *dacContrast) close() {
+	c.f.Close()
+}
@@ -0,0 +1,159 @@
+// Package daemon lets shell scripts update a Device through a Unix socket or a named pipe (FIFO),
+// with a simple line protocol, e.g.
+//
+//	echo "@1,0 Hello" > /run/lcd.fifo
+//	printf 'CLEAR\nLED ON\n' | socat - UNIX-CONNECT:/run/lcd.sock
+//
+// Each line is one command, and the commands are case insensitive
+//
+//	@row,col text	Prints the text at the row and col (from 0), the text starts after the first space
+//	CLEAR		Clears the display
+//	HOME		Moves the cursor to the top left
+//	LED ON|OFF	Turns the backlight on or off
+//	CURSOR ON|OFF	Shows or hides the cursor
+//	BLINK ON|OFF	Turns blinking of the cursor on or off
+//	DIAG [seconds]	Shows the diagnostics page, each display full for the seconds (3 by default)
+//
+// Empty lines, and lines starting with #, are ignored. There is no authentication, so restrict
+// access to the socket or pipe by its file permissions
+package daemon
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hossner/go-st7066u"
+)
+
+var errOnOff = errors.New("Expected ON or OFF")
+
+// Exec executes the command of the line on the device
+func Exec(dev *st7066u.Device, line string) error {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+	if strings.HasPrefix(line, "@") {
+		return printAt(dev, line[1:])
+	}
+	fields := strings.Fields(line)
+	cmd, args := strings.ToUpper(fields[0]), fields[1:]
+	switch cmd {
+	case "CLEAR", "HOME":
+		if len(args) > 0 {
+			return fmt.Errorf("%s takes no arguments", cmd)
+		}
+		if cmd == "CLEAR" {
+			return dev.Clear()
+		}
+		return dev.Home()
+	case "DIAG":
+		return showDiagnostics(dev, args)
+	case "LED", "CURSOR", "BLINK":
+		if len(args) != 1 {
+			return fmt.Errorf("%s: %v", cmd, errOnOff)
+		}
+		var on bool
+		switch strings.ToUpper(args[0]) {
+		case "ON":
+			on = true
+		case "OFF":
+		default:
+			return fmt.Errorf("%s: %v", cmd, errOnOff)
+		}
+		switch cmd {
+		case "LED":
+			dev.LedOn(on)
+		case "CURSOR":
+			dev.CursorOn(on)
+		default:
+			dev.CursorBlink(on)
+		}
+		return nil
+	}
+	return fmt.Errorf("Unknown command %q", fields[0])
+}
+
+// showDiagnostics executes the DIAG command, returning when the diagnostics page has been shown
+func showDiagnostics(dev *st7066u.Device, args []string) error {
+	secs := 3.0
+	if len(args) > 1 {
+		return errors.New("DIAG takes at most one argument")
+	}
+	if len(args) == 1 {
+		var err error
+		secs, err = strconv.ParseFloat(args[0], 64)
+		if err != nil || secs <= 0 || secs > 60 {
+			return errors.New("DIAG: seconds must be a number from 0 to 60")
+		}
+	}
+	dev.ShowDiagnostics(time.Duration(secs * float64(time.Second)))
+	return nil
+}
+
+// printAt executes the @ command, given without the @
+func printAt(dev *st7066u.Device, cmd string) error {
+	pos, text := cmd, ""
+	if i := strings.IndexByte(cmd, ' '); i >= 0 {
+		pos, text = cmd[:i], cmd[i+1:]
+	}
+	rows, cols := dev.Size()
+	rc := strings.Split(pos, ",")
+	if len(rc) != 2 {
+		return fmt.Errorf("Expected @row,col but got @%s", pos)
+	}
+	row, err := strconv.Atoi(rc[0])
+	if err != nil || row < 0 || row >= int(rows) {
+		return fmt.Errorf("Row must be a number from 0 to %d", rows-1)
+	}
+	col, err := strconv.Atoi(rc[1])
+	if err != nil || col < 0 || col >= int(cols) {
+		return fmt.Errorf("Col must be a number from 0 to %d", cols-1)
+	}
+	dev.PrintAt(uint8(row), uint8(col), text)
+	return nil
+}
+
+// Serve executes the commands read from r, line by line, until the end of r. If w isn't nil, an
+// error of a command is written to it as a line "ERR <error>", and the remaining commands are still
+// executed. An error is returned only if reading fails
+func Serve(r io.Reader, w io.Writer, dev *st7066u.Device) error {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		if err := Exec(dev, s.Text()); err != nil && w != nil {
+			fmt.Fprintf(w, "ERR %v\n", err)
+		}
+	}
+	return s.Err()
+}
+
+// ListenUnix listens on a Unix socket at the path, and serves the commands of each connection (see
+// func Serve), with errors written back on the connection, until accepting a connection fails. A
+// socket left at the path, e.g. by a program that crashed, is removed first
+func ListenUnix(path string, dev *st7066u.Device) error {
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		os.Remove(path)
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			Serve(conn, conn, dev)
+		}()
+	}
+}
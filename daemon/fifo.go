@@ -0,0 +1,38 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+// +build linux darwin freebsd netbsd openbsd
+
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/hossner/go-st7066u"
+)
+
+// ServeFIFO serves the commands written to the named pipe at the path (see func Serve), creating the
+// pipe if there is none. The pipe is opened again each time the last writer closes it, so that any
+// number of scripts can write to it one after another. It returns only if the pipe can't be created
+// or opened
+func ServeFIFO(path string, dev *st7066u.Device) error {
+	fi, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err):
+		if err := syscall.Mkfifo(path, 0o620); err != nil {
+			return &os.PathError{Op: "mkfifo", Path: path, Err: err}
+		}
+	case err != nil:
+		return err
+	case fi.Mode()&os.ModeNamedPipe == 0:
+		return fmt.Errorf("%s isn't a named pipe", path)
+	}
+	for {
+		f, err := os.Open(path) // Blocks until a writer opens the pipe
+		if err != nil {
+			return err
+		}
+		Serve(f, nil, dev)
+		f.Close()
+	}
+}
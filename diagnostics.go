@@ -0,0 +1,97 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// Diagnostics returns the lines of the diagnostics page: uptime of the device, updates written to
+// the display per second, the nr of bus errors (see Stats), the first non-loopback IPv4 address of
+// the host and the backend used. On narrow displays
+// the labels are left out of lines that wouldn't fit otherwise, and lines are truncated to the
+// width of the display
+func (l *Device) Diagnostics() []string {
+	l.mu.Lock()
+	up := time.Since(l.opened)
+	updates, errs := l.updates, l.busErrors
+	backend := l.backend
+	l.mu.Unlock()
+	rate := float64(updates) / up.Seconds()
+	items := [][2]string{
+		{"Up", fmtUptime(up)},
+		{"Refresh", fmt.Sprintf("%.1f/s", rate)},
+		{"Errors", strconv.FormatUint(errs, 10)},
+		{"IP", hostIP()},
+		{"Bus", backend},
+	}
+	lines := make([]string, len(items))
+	for i, item := range items {
+		line := item[0] + " " + item[1]
+		if len(line) > int(l.cols) {
+			line = item[1]
+		}
+		if r := []rune(line); len(r) > int(l.cols) {
+			line = string(r[:l.cols])
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// ShowDiagnostics shows the diagnostics page (see func Diagnostics), one display full of lines at a
+// time, each shown for the duration d. The previous content of the display is then restored. Any
+// changes made by others while the page is shown are overwritten
+func (l *Device) ShowDiagnostics(d time.Duration) {
+	var saved *Frame
+	l.Update(func(f *Frame) {
+		saved = f.clone()
+	})
+	lines := l.Diagnostics()
+	for i := 0; i < len(lines); i += int(l.rows) {
+		i := i // The update may run later, in async mode
+		l.Update(func(f *Frame) {
+			f.Clear()
+			for r := 0; r < int(l.rows) && i+r < len(lines); r++ {
+				f.PrintAt(uint8(r), 0, lines[i+r])
+			}
+		})
+		time.Sleep(d)
+	}
+	l.Update(func(f *Frame) {
+		f.copyFrom(saved)
+	})
+}
+
+// fmtUptime formats the duration compactly, e.g. "3d04h12m" or "12m05s"
+func fmtUptime(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	h := int(d.Hours()) % 24
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%02dh%02dm", days, h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh%02dm%02ds", h, m, s)
+	}
+	return fmt.Sprintf("%dm%02ds", m, s)
+}
+
+// hostIP returns the first non-loopback IPv4 address of the host, or "-" if there is none
+func hostIP() string {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return "-"
+	}
+	for _, a := range addrs {
+		if ip, ok := a.(*net.IPNet); ok && !ip.IP.IsLoopback() && ip.IP.To4() != nil {
+			return ip.IP.String()
+		}
+	}
+	return "-"
+}
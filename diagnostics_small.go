@@ -0,0 +1,16 @@
+//go:build st7066u_small
+// +build st7066u_small
+
+package st7066u
+
+import "time"
+
+// Diagnostics returns no lines, as the diagnostics page is left out with the build tag
+// st7066u_small, see the Diagnostics of other builds
+func (l *Device) Diagnostics() []string {
+	return nil
+}
+
+// ShowDiagnostics does nothing, as the diagnostics page is left out with the build tag
+// st7066u_small, see the ShowDiagnostics of other builds
+func (l *Device) ShowDiagnostics(d time.Duration) {}
@@ -0,0 +1,70 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+import "time"
+
+// cursorBlink is how long a blinking cursor is shown, and hidden
+const cursorBlink = time.Microsecond * 409600
+
+// DotMatrix is what the display shows at an instant, dot by dot, see func Dots
+type DotMatrix struct {
+	Rows, Cols    uint8    // Nr of rows and columns of characters
+	Width, Height int      // Nr of dots of a character, 5 x 8, or 5 x 11 with 5x11 dot characters
+	Dots          [][]bool // Rows of dots from the top, Rows*Height rows of Cols*Width dots, without the gaps between characters
+	Backlight     bool     // The backlight is on
+}
+
+// Dots returns what the display shows, dot by dot, drawn from the content written to it, the font of
+// ROM code A00 (also for other ROMs, see SetROM) and the user-defined characters. The cursor is drawn if shown, and a
+// blinking cursor as it is shown at the instant. All dots are off while the display is turned off
+func (l *Device) Dots() DotMatrix {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	m := DotMatrix{Rows: l.rows, Cols: l.cols, Width: 5, Height: 8, Backlight: l.backlightOn()}
+	if l.sym == DOTS5x11 {
+		m.Height = 11
+	}
+	m.Dots = make([][]bool, int(l.rows)*m.Height)
+	for i := range m.Dots {
+		m.Dots[i] = make([]bool, int(l.cols)*m.Width)
+	}
+	display := l.displayCtl
+	if display&(1<<2) == 0 || l.idle && l.idleDisplay {
+		return m
+	}
+	for row := uint8(0); row < l.rows; row++ {
+		for col := uint8(0); col < l.cols; col++ {
+			g := l.codeGlyph(l.cell(row, col))
+			for y := 0; y < len(g) && y < m.Height; y++ {
+				for x := 0; x < m.Width; x++ {
+					m.Dots[int(row)*m.Height+y][int(col)*m.Width+x] = g[y]&(0x10>>x) != 0
+				}
+			}
+		}
+	}
+	if row, col, ok := l.cursor(); ok {
+		top, left := int(row)*m.Height, int(col)*m.Width
+		for x := 0; x < m.Width; x++ {
+			if display&0b10 != 0 {
+				m.Dots[top+m.Height-1][left+x] = true // Underline
+			}
+			if display&0b01 != 0 && time.Now().UnixNano()/int64(cursorBlink)%2 == 0 {
+				for y := 0; y < m.Height; y++ {
+					m.Dots[top+y][left+x] = true
+				}
+			}
+		}
+	}
+	return m
+}
+
+// codeGlyph returns the pattern of the character code; the glyph of a user-defined character, or of
+// the ROM font
+func (l *Device) codeGlyph(code byte) Glyph {
+	if i, ok := l.codeSlot(code); ok {
+		return l.slots[i].g
+	}
+	return romFont[code]
+}
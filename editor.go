@@ -0,0 +1,249 @@
+package st7066u
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// editorBlink is how long the edited field of an Editor is shown and hidden when blinking
+const editorBlink = time.Millisecond * 500
+
+// Editor adjusts a numeric or enum setting in place, in a field on one row of the display, e.g. on
+// a settings screen. While editing, the field blinks, and Increase and Decrease step the value
+// within its range; Select confirms the value, and Back cancels the editing, restoring the value it
+// had. Drive it with buttons or a rotary encoder, see func Adjust of package input. Use func
+// NewNumberEditor or NewEnumEditor to get a new struct
+type Editor struct {
+	l                 *Device
+	mu                sync.Mutex
+	row, col, width   uint8
+	options           []string // The values of an enum setting, nil for a numeric setting
+	min, step         float64
+	format            string // Format of a numeric value, e.g. "%.1fV"
+	n, count          int    // The value, as the nr of steps from min or the enum index, and the nr of values
+	start             int    // The value when the editing started
+	wrap              bool
+	editing, hidden   bool
+	changed           time.Time // When the value was last changed, to keep it shown while adjusted
+	stop, blinkerDone chan struct{}
+}
+
+// NewNumberEditor returns an Editor of a numeric setting from min to max, in steps of step, at the
+// row and col and width cells wide. The value is rounded to the nearest step within the range, and
+// shown right-aligned with as many decimals as step has (see SetFormat). The editor is drawn and
+// starts editing
+func (l *Device) NewNumberEditor(row, col, width uint8, min, max, step, value float64) *Editor {
+	count := 1
+	if step > 0 && max > min {
+		count = int(math.Floor((max-min)/step+1e-9)) + 1
+	}
+	e := &Editor{l: l, row: row, col: col, width: width, min: min, step: step, count: count}
+	decimals := 0
+	if s := strconv.FormatFloat(step, 'f', -1, 64); strings.Contains(s, ".") {
+		decimals = len(s) - strings.Index(s, ".") - 1
+	}
+	e.format = fmt.Sprintf("%%.%df", decimals)
+	if step > 0 {
+		e.n = e.clamp(int(math.Round((value - min) / step)))
+	}
+	e.Edit()
+	return e
+}
+
+// NewEnumEditor returns an Editor of an enum setting, one of the options, at the row and col and
+// width cells wide. The option of the index is selected, and shown left-aligned. The editor is drawn
+// and starts editing
+func (l *Device) NewEnumEditor(row, col, width uint8, options []string, index int) *Editor {
+	e := &Editor{l: l, row: row, col: col, width: width, options: options, count: len(options)}
+	e.n = e.clamp(index)
+	e.Edit()
+	return e
+}
+
+// SetFormat sets the format of a numeric value, as for fmt.Sprintf, e.g. "%.1fV" or "%3.0f%%"
+func (e *Editor) SetFormat(format string) {
+	e.mu.Lock()
+	e.format = format
+	e.mu.Unlock()
+	e.draw()
+}
+
+// SetWrap sets if stepping past one end of the range continues at the other end, off by default
+func (e *Editor) SetWrap(on bool) {
+	e.mu.Lock()
+	e.wrap = on
+	e.mu.Unlock()
+}
+
+// Increase steps the value up, if editing and not at the end of the range
+func (e *Editor) Increase() {
+	e.adjust(1)
+}
+
+// Decrease steps the value down, if editing and not at the start of the range
+func (e *Editor) Decrease() {
+	e.adjust(-1)
+}
+
+// Edit starts editing the value, blinking the field, if not editing already
+func (e *Editor) Edit() {
+	e.mu.Lock()
+	if e.editing {
+		e.mu.Unlock()
+		return
+	}
+	e.editing, e.hidden, e.start = true, false, e.n
+	e.stop, e.blinkerDone = make(chan struct{}), make(chan struct{})
+	go e.blink(e.stop, e.blinkerDone)
+	e.mu.Unlock()
+	e.draw()
+}
+
+// Select confirms the value and stops editing, leaving the field shown
+func (e *Editor) Select() {
+	e.stopEditing(false)
+}
+
+// Back cancels the editing, restoring the value it had when the editing started. false is returned
+// if not editing
+func (e *Editor) Back() bool {
+	return e.stopEditing(true)
+}
+
+// Editing returns true if the value is being edited
+func (e *Editor) Editing() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.editing
+}
+
+// Value returns the value of a numeric setting, or the index of the option of an enum setting
+func (e *Editor) Value() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.options != nil {
+		return float64(e.n)
+	}
+	return e.min + float64(e.n)*e.step
+}
+
+// Index returns the index of the option of an enum setting, or the nr of steps from min of a numeric
+// setting
+func (e *Editor) Index() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.n
+}
+
+// Text returns the value as shown, without padding
+func (e *Editor) Text() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.text()
+}
+
+// Redraw draws the editor again, e.g. after the display has been used for something else
+func (e *Editor) Redraw() {
+	e.draw()
+}
+
+// adjust steps the value by delta steps while editing, within the range or wrapping around it
+func (e *Editor) adjust(delta int) {
+	e.mu.Lock()
+	if !e.editing || e.count == 0 {
+		e.mu.Unlock()
+		return
+	}
+	n := e.n + delta
+	if e.wrap {
+		n = (n%e.count + e.count) % e.count
+	}
+	if n < 0 || n >= e.count || n == e.n {
+		e.mu.Unlock()
+		return
+	}
+	e.n, e.hidden, e.changed = n, false, time.Now()
+	e.mu.Unlock()
+	e.draw()
+}
+
+// stopEditing stops editing and blinking, restoring the value if cancel is true. false is returned
+// if not editing
+func (e *Editor) stopEditing(cancel bool) bool {
+	e.mu.Lock()
+	if !e.editing {
+		e.mu.Unlock()
+		return false
+	}
+	e.editing, e.hidden = false, false
+	if cancel {
+		e.n = e.start
+	}
+	stop, done := e.stop, e.blinkerDone
+	e.mu.Unlock()
+	close(stop)
+	<-done
+	e.draw()
+	return true
+}
+
+// blink shows and hides the field until stop is closed, keeping it shown for a while after the value
+// has been changed
+func (e *Editor) blink(stop, done chan struct{}) {
+	defer close(done)
+	t := time.NewTicker(editorBlink)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-t.C:
+			e.mu.Lock()
+			toggle := e.hidden || now.Sub(e.changed) >= editorBlink
+			if toggle {
+				e.hidden = !e.hidden
+			}
+			e.mu.Unlock()
+			if toggle {
+				e.draw()
+			}
+		}
+	}
+}
+
+// text returns the value as shown
+func (e *Editor) text() string {
+	if e.options != nil {
+		if e.n < len(e.options) {
+			return e.options[e.n]
+		}
+		return ""
+	}
+	return fmt.Sprintf(e.format, e.min+float64(e.n)*e.step)
+}
+
+// clamp returns n within the range of values
+func (e *Editor) clamp(n int) int {
+	if n >= e.count {
+		n = e.count - 1
+	}
+	if n < 0 {
+		n = 0
+	}
+	return n
+}
+
+// draw draws the field as it is now, leaving the cursor where it was
+func (e *Editor) draw() {
+	e.mu.Lock()
+	row, col, width, text, right := e.row, e.col, e.width, e.text(), e.options == nil
+	if e.hidden {
+		text = ""
+	}
+	e.mu.Unlock()
+	e.l.Update(func(f *Frame) { f.printField(row, col, width, text, right) })
+}
@@ -0,0 +1,144 @@
+package st7066u
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// breatheStep is how often the brightness is changed while breathing
+const breatheStep = time.Millisecond * 20
+
+// Effect is an effect running in a goroutine, see func BlinkBacklight, BreatheBacklight and
+// BlinkRegion
+type Effect struct {
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// BlinkBacklight blinks the backlight the nr of times, turning it off and on again once per period,
+// e.g. for attention-getting alerts. A nr of times of 0 or less blinks until stopped. The blinking
+// runs in a goroutine; call Stop on the returned struct to stop it early, or Wait to wait for it to
+// end. The backlight is left as it was
+func (l *Device) BlinkBacklight(times int, period time.Duration) *Effect {
+	e := newEffect()
+	go func() {
+		defer close(e.done)
+		l.blinkBacklight(times, period, e.stop)
+	}()
+	return e
+}
+
+// BreatheBacklight fades the backlight in and out, from off to the current brightness and back once
+// per period, until stopped. This requires the brightness to be settable, i.e. the L pin to be a
+// hardware PWM pin (see SetBrightness), or an RGB backlight (see SetRGBPins). The breathing runs in
+// a goroutine; call Stop on the returned struct to stop it, leaving the backlight as it was
+func (l *Device) BreatheBacklight(period time.Duration) (*Effect, error) {
+	if l.hasPwmBacklight() {
+		if err := l.SetBrightness(l.Brightness()); err != nil {
+			return nil, err
+		}
+	} else if l.rgb == nil {
+		return nil, errors.New("Breathing requires the L pin to be a hardware PWM pin, or an RGB backlight")
+	}
+	if period <= 0 {
+		return nil, errors.New("The period must be positive")
+	}
+	e := newEffect()
+	go func() {
+		defer close(e.done)
+		var on bool
+		var level float64
+		l.doWait(func() { on, level = l.ledOn, l.brightness })
+		defer l.do(func() {
+			l.ledOn, l.brightness = on, level
+			l.applyBacklight()
+		})
+		t := time.NewTicker(breatheStep)
+		defer t.Stop()
+		start := time.Now()
+		for {
+			phase := 2 * math.Pi * float64(time.Since(start)%period) / float64(period)
+			b := level * (1 - math.Cos(phase)) / 2
+			l.do(func() {
+				l.ledOn, l.brightness = true, b
+				l.applyBacklight()
+			})
+			select {
+			case <-e.stop:
+				return
+			case <-t.C:
+			}
+		}
+	}()
+	return e, nil
+}
+
+// BlinkRegion blinks the text of a region of a row, showing it and spaces in its place for half the
+// period each, until stopped, as the controller only blinks the cursor. The spaces are written to the
+// display only; what is written to the region while it blinks is shown as it blinks, and Update
+// sees the text, not the spaces. The blinking runs in a goroutine; call Stop on the
+// returned struct to stop it, leaving the text shown
+func (l *Device) BlinkRegion(row, col, width uint8, period time.Duration) *Effect {
+	e := newEffect()
+	go func() {
+		defer close(e.done)
+		defer l.do(func() { l.blankRegion(row, col, width, false) })
+		for blank := true; ; blank = !blank {
+			blank := blank
+			l.do(func() { l.blankRegion(row, col, width, blank) })
+			select {
+			case <-e.stop:
+				return
+			case <-time.After(period / 2):
+			}
+		}
+	}()
+	return e
+}
+
+// blankRegion writes spaces to the region of the row if blank is true, without changing what is
+// kept as written to it, and what is written to it otherwise. The cursor is left where it was
+func (l *Device) blankRegion(row, col, width uint8, blank bool) {
+	if row >= l.rows || col >= l.cols {
+		return
+	}
+	addr, ctl, held := l.addr, l.ctl, l.teeHeld
+	l.teeHeld = true
+	defer func() { l.teeHeld = held }()
+	for c := col; c < l.cols && c-col < width; c++ {
+		if c == col || !l.geo.follows(c) {
+			l.setAddr(l.geo.cellAddr(row, c))
+		}
+		ch := byte(' ')
+		if !blank {
+			ch = l.cell(row, c)
+		}
+		l.write(ch, cmdData)
+	}
+	l.restoreAddrs(addr, ctl)
+}
+
+// Stop stops the effect and waits for it to end, leaving the backlight, or the text of the region
+// blinked, as it was
+func (e *Effect) Stop() {
+	e.stopOnce.Do(func() { close(e.stop) })
+	<-e.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (e *Effect) Done() <-chan struct{} {
+	return e.done
+}
+
+// Wait waits for the effect to end
+func (e *Effect) Wait() {
+	<-e.done
+}
+
+// newEffect returns a new Effect, to be run by a goroutine closing done when it ends
+func newEffect() *Effect {
+	return &Effect{stop: make(chan struct{}), done: make(chan struct{})}
+}
@@ -0,0 +1,66 @@
+// Jukebox shows the title of the playing track scrolling on the first row, and the elapsed and total
+// time on the second, as the display of a small music player would. It runs against the simulator
+// and prints the display after each update; use st7066u.New instead of st7066u.NewSimulated to run
+// it on a real display
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hossner/go-st7066u"
+)
+
+type track struct {
+	title  string
+	length time.Duration
+}
+
+func main() {
+	lcd, sim, err := st7066u.NewSimulated(2, 16, st7066u.DOTS5x8, st7066u.BITMODE8)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer lcd.Close()
+	lcd.LedOn(true)
+
+	playlist := []track{
+		{"Bohemian Rhapsody - Queen", time.Minute*5 + time.Second*55},
+		{"Heroes - David Bowie", time.Minute*6 + time.Second*7},
+	}
+	for n, t := range playlist {
+		// Each step shows one more second of the track; only the first few seconds are shown
+		for sec := 0; sec < 12; sec++ {
+			show(lcd, n+1, t, time.Duration(sec)*time.Second, sec)
+			fmt.Print(sim)
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+}
+
+// show writes the track to the display, with the title scrolled 'step' positions
+func show(lcd *st7066u.Device, nr int, t track, elapsed time.Duration, step int) {
+	lcd.PrintAt(0, 0, marquee(fmt.Sprintf("%d. %s", nr, t.title), 16, step))
+	lcd.PrintAt(1, 0, fmt.Sprintf("%s / %s   ", clock(elapsed), clock(t.length)))
+}
+
+// marquee returns the window of the text, 'width' runes wide, scrolled 'step' positions. Texts
+// fitting the width are padded, not scrolled
+func marquee(text string, width, step int) string {
+	r := []rune(text)
+	if len(r) <= width {
+		return fmt.Sprintf("%-*s", width, text)
+	}
+	r = append(r, []rune("   ")...)
+	out := make([]rune, width)
+	for i := range out {
+		out[i] = r[(step+i)%len(r)]
+	}
+	return string(out)
+}
+
+// clock formats the duration as mm:ss
+func clock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hossner/go-st7066u"
+)
+
+func TestShow(t *testing.T) {
+	heroes := track{"Heroes - David Bowie", time.Minute*6 + time.Second*7}
+	tests := []struct {
+		name    string
+		nr      int
+		t       track
+		elapsed time.Duration
+		step    int
+		want    []string
+	}{
+		{"start", 1, heroes, 0, 0, []string{"1. Heroes - Davi", "00:00 / 06:07   "}},
+		{"scrolled", 1, heroes, time.Second * 3, 3, []string{"Heroes - David B", "00:03 / 06:07   "}},
+		{"wrapped", 1, heroes, time.Second * 20, 20, []string{"wie   1. Heroes ", "00:20 / 06:07   "}},
+		{"short title", 2, track{"Help!", time.Minute * 2}, time.Second * 65, 5, []string{"2. Help!        ", "01:05 / 02:00   "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lcd, sim, err := st7066u.NewSimulated(2, 16, st7066u.DOTS5x8, st7066u.BITMODE8)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer lcd.Close()
+			lcd.SetTiming(st7066u.Timing{})
+			show(lcd, tt.nr, tt.t, tt.elapsed, tt.step)
+			for r, line := range sim.Lines() {
+				if line != tt.want[r] {
+					t.Errorf("Row %d: got %q, want %q", r, line, tt.want[r])
+				}
+			}
+		})
+	}
+}
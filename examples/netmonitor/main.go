@@ -0,0 +1,90 @@
+// Netmonitor shows the network interfaces of the host, one per row with its first IPv4 address,
+// rotating through them when there are more interfaces than rows. It runs against the simulator and
+// prints the display after each update; use st7066u.New instead of st7066u.NewSimulated to run it
+// on a real display
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"time"
+
+	"github.com/hossner/go-st7066u"
+)
+
+type iface struct {
+	name string
+	addr string
+}
+
+func main() {
+	lcd, sim, err := st7066u.NewSimulated(2, 20, st7066u.DOTS5x8, st7066u.BITMODE8)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer lcd.Close()
+	lcd.LedOn(true)
+
+	ifaces, err := interfaces()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	for i := 0; i == 0 || i < len(ifaces); i += int(lcd.Rows()) {
+		show(lcd, ifaces, i)
+		fmt.Print(sim)
+		time.Sleep(time.Millisecond * 500)
+	}
+}
+
+// show writes the interfaces from the i:th on to the display, one per row, or "No network" if
+// there are none
+func show(lcd *st7066u.Device, ifaces []iface, i int) {
+	lcd.Clear()
+	if len(ifaces) == 0 {
+		lcd.PrintAt(0, 0, "No network")
+		return
+	}
+	for row := 0; row < int(lcd.Rows()) && i+row < len(ifaces); row++ {
+		lcd.PrintAt(uint8(row), 0, format(ifaces[i+row], int(lcd.Cols())))
+	}
+}
+
+// interfaces returns the interfaces that are up, with their first IPv4 address
+func interfaces() ([]iface, error) {
+	all, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	var ifaces []iface
+	for _, ni := range all {
+		if ni.Flags&net.FlagUp == 0 {
+			continue
+		}
+		addrs, err := ni.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		i := iface{name: ni.Name, addr: "-"}
+		for _, a := range addrs {
+			if ip, ok := a.(*net.IPNet); ok && ip.IP.To4() != nil {
+				i.addr = ip.IP.String()
+				break
+			}
+		}
+		ifaces = append(ifaces, i)
+	}
+	return ifaces, nil
+}
+
+// format returns the interface as "name addr", with the name shortened to fit the width
+func format(i iface, width int) string {
+	name := i.name
+	if max := width - len(i.addr) - 1; len(name) > max {
+		if max < 0 {
+			max = 0
+		}
+		name = name[:max]
+	}
+	return fmt.Sprintf("%-*s%s", width-len(i.addr), name, i.addr)
+}
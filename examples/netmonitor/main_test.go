@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hossner/go-st7066u"
+)
+
+func TestShow(t *testing.T) {
+	ifaces := []iface{
+		{"eth0", "192.168.1.10"},
+		{"wlp3s0verylongname", "10.0.0.1"},
+		{"lo", "127.0.0.1"},
+	}
+	tests := []struct {
+		name   string
+		ifaces []iface
+		i      int
+		want   []string
+	}{
+		{"first page", ifaces, 0, []string{"eth0    192.168.1.10", "wlp3s0veryl 10.0.0.1"}},
+		{"last page", ifaces, 2, []string{"lo         127.0.0.1", "                    "}},
+		{"no network", nil, 0, []string{"No network          ", "                    "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lcd, sim, err := st7066u.NewSimulated(2, 20, st7066u.DOTS5x8, st7066u.BITMODE8)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer lcd.Close()
+			lcd.SetTiming(st7066u.Timing{})
+			show(lcd, ifaces, 0)
+			show(lcd, tt.ifaces, tt.i)
+			for r, line := range sim.Lines() {
+				if line != tt.want[r] {
+					t.Errorf("Row %d: got %q, want %q", r, line, tt.want[r])
+				}
+			}
+		})
+	}
+}
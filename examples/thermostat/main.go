@@ -0,0 +1,45 @@
+// Thermostat shows the current and the target temperature, and if the heating is on, as a small
+// thermostat UI would. It runs against the simulator and prints the display after each update; use
+// st7066u.New instead of st7066u.NewSimulated to run it on a real display
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hossner/go-st7066u"
+)
+
+func main() {
+	lcd, sim, err := st7066u.NewSimulated(2, 16, st7066u.DOTS5x8, st7066u.BITMODE8)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer lcd.Close()
+	lcd.LedOn(true)
+
+	target := 21.0
+	temp := 18.5
+	for i := 0; i < 8; i++ {
+		heating := temp < target
+		if heating {
+			temp += 0.7
+		} else {
+			temp -= 0.2
+		}
+		show(lcd, temp, target, heating)
+		fmt.Print(sim)
+		time.Sleep(time.Millisecond * 250)
+	}
+}
+
+// show writes the thermostat UI to the display
+func show(lcd *st7066u.Device, temp, target float64, heating bool) {
+	state := "Idle"
+	if heating {
+		state = "Heat"
+	}
+	lcd.PrintAt(0, 0, fmt.Sprintf("Temp %5.1f C    ", temp))
+	lcd.PrintAt(1, 0, fmt.Sprintf("Set  %5.1f %s ", target, state))
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/hossner/go-st7066u"
+)
+
+func TestShow(t *testing.T) {
+	tests := []struct {
+		name         string
+		temp, target float64
+		heating      bool
+		want         []string
+	}{
+		{"heating", 18.5, 21, true, []string{"Temp  18.5 C    ", "Set   21.0 Heat "}},
+		{"idle", 21.3, 21, false, []string{"Temp  21.3 C    ", "Set   21.0 Idle "}},
+		{"below zero", -5.25, 7.5, true, []string{"Temp  -5.2 C    ", "Set    7.5 Heat "}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lcd, sim, err := st7066u.NewSimulated(2, 16, st7066u.DOTS5x8, st7066u.BITMODE8)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer lcd.Close()
+			lcd.SetTiming(st7066u.Timing{})
+			show(lcd, tt.temp, tt.target, tt.heating)
+			for r, line := range sim.Lines() {
+				if line != tt.want[r] {
+					t.Errorf("Row %d: got %q, want %q", r, line, tt.want[r])
+				}
+			}
+		})
+	}
+}
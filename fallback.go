@@ -0,0 +1,214 @@
+package st7066u
+
+// fallbackGlyphs are the patterns of letters missing from the ROMs, see func SetGlyphFallback. Letters
+// with a diacritic above are drawn in the lower 5 rows of the character, with the diacritic above
+// them, and letters with a diacritic below in the upper 6 rows
+var fallbackGlyphs = map[rune]Glyph{
+	'À': {0x08, 0x04, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'Á': {0x02, 0x04, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'Â': {0x04, 0x0a, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'Ã': {0x0d, 0x16, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'Ä': {0x0a, 0x00, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'Å': {0x0e, 0x0a, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'Æ': {0x0f, 0x14, 0x14, 0x1f, 0x14, 0x14, 0x17, 0x00},
+	'Ç': {0x0e, 0x11, 0x10, 0x10, 0x11, 0x0e, 0x04, 0x08},
+	'È': {0x08, 0x04, 0x1f, 0x10, 0x1e, 0x10, 0x1f, 0x00},
+	'É': {0x02, 0x04, 0x1f, 0x10, 0x1e, 0x10, 0x1f, 0x00},
+	'Ê': {0x04, 0x0a, 0x1f, 0x10, 0x1e, 0x10, 0x1f, 0x00},
+	'Ë': {0x0a, 0x00, 0x1f, 0x10, 0x1e, 0x10, 0x1f, 0x00},
+	'Ì': {0x08, 0x04, 0x0e, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Í': {0x02, 0x04, 0x0e, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Î': {0x04, 0x0a, 0x0e, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Ï': {0x0a, 0x00, 0x0e, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Ð': {0x1c, 0x12, 0x11, 0x1d, 0x11, 0x12, 0x1c, 0x00},
+	'Ñ': {0x0d, 0x16, 0x11, 0x19, 0x15, 0x13, 0x11, 0x00},
+	'Ò': {0x08, 0x04, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ó': {0x02, 0x04, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ô': {0x04, 0x0a, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Õ': {0x0d, 0x16, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ö': {0x0a, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ø': {0x0e, 0x13, 0x13, 0x15, 0x19, 0x19, 0x0e, 0x00},
+	'Ù': {0x08, 0x04, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ú': {0x02, 0x04, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Û': {0x04, 0x0a, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ü': {0x0a, 0x00, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ý': {0x02, 0x04, 0x11, 0x11, 0x0a, 0x04, 0x04, 0x00},
+	'Þ': {0x10, 0x1e, 0x11, 0x11, 0x1e, 0x10, 0x10, 0x00},
+	'à': {0x08, 0x04, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00},
+	'á': {0x02, 0x04, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00},
+	'â': {0x04, 0x0a, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00},
+	'ã': {0x0d, 0x16, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00},
+	'ä': {0x0a, 0x00, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00},
+	'å': {0x0e, 0x0a, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00},
+	'æ': {0x00, 0x00, 0x1a, 0x05, 0x1f, 0x14, 0x0b, 0x00},
+	'ç': {0x00, 0x0e, 0x10, 0x10, 0x11, 0x0e, 0x04, 0x08},
+	'è': {0x08, 0x04, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00},
+	'é': {0x02, 0x04, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00},
+	'ê': {0x04, 0x0a, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00},
+	'ë': {0x0a, 0x00, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00},
+	'ì': {0x08, 0x04, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'í': {0x02, 0x04, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'î': {0x04, 0x0a, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'ï': {0x0a, 0x00, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'ð': {0x05, 0x02, 0x0d, 0x01, 0x0f, 0x11, 0x0e, 0x00},
+	'ñ': {0x0d, 0x16, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00},
+	'ò': {0x08, 0x04, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ó': {0x02, 0x04, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ô': {0x04, 0x0a, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'õ': {0x0d, 0x16, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ö': {0x0a, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ø': {0x00, 0x00, 0x0e, 0x13, 0x15, 0x19, 0x0e, 0x00},
+	'ù': {0x08, 0x04, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'ú': {0x02, 0x04, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'û': {0x04, 0x0a, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'ü': {0x0a, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'ý': {0x02, 0x04, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x00},
+	'þ': {0x10, 0x10, 0x1e, 0x11, 0x1e, 0x10, 0x10, 0x00},
+	'ÿ': {0x0a, 0x00, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x00},
+	'Ā': {0x0e, 0x00, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'ā': {0x0e, 0x00, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00},
+	'Ă': {0x11, 0x0e, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'ă': {0x11, 0x0e, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00},
+	'Ą': {0x0e, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x02, 0x03},
+	'ą': {0x00, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x02, 0x03},
+	'Ć': {0x02, 0x04, 0x0e, 0x11, 0x10, 0x11, 0x0e, 0x00},
+	'ć': {0x02, 0x04, 0x0e, 0x10, 0x10, 0x11, 0x0e, 0x00},
+	'Ĉ': {0x04, 0x0a, 0x0e, 0x11, 0x10, 0x11, 0x0e, 0x00},
+	'ĉ': {0x04, 0x0a, 0x0e, 0x10, 0x10, 0x11, 0x0e, 0x00},
+	'Ċ': {0x04, 0x00, 0x0e, 0x11, 0x10, 0x11, 0x0e, 0x00},
+	'ċ': {0x04, 0x00, 0x0e, 0x10, 0x10, 0x11, 0x0e, 0x00},
+	'Č': {0x0a, 0x04, 0x0e, 0x11, 0x10, 0x11, 0x0e, 0x00},
+	'č': {0x0a, 0x04, 0x0e, 0x10, 0x10, 0x11, 0x0e, 0x00},
+	'Ď': {0x0a, 0x04, 0x1c, 0x12, 0x11, 0x12, 0x1c, 0x00},
+	'ď': {0x01, 0x05, 0x0d, 0x13, 0x11, 0x11, 0x0f, 0x00},
+	'Đ': {0x1c, 0x12, 0x11, 0x1d, 0x11, 0x12, 0x1c, 0x00},
+	'đ': {0x02, 0x07, 0x0e, 0x12, 0x12, 0x12, 0x0e, 0x00},
+	'Ē': {0x0e, 0x00, 0x1f, 0x10, 0x1e, 0x10, 0x1f, 0x00},
+	'ē': {0x0e, 0x00, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00},
+	'Ĕ': {0x11, 0x0e, 0x1f, 0x10, 0x1e, 0x10, 0x1f, 0x00},
+	'ĕ': {0x11, 0x0e, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00},
+	'Ė': {0x04, 0x00, 0x1f, 0x10, 0x1e, 0x10, 0x1f, 0x00},
+	'ė': {0x04, 0x00, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00},
+	'Ę': {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x1f, 0x02, 0x03},
+	'ę': {0x00, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x02, 0x03},
+	'Ě': {0x0a, 0x04, 0x1f, 0x10, 0x1e, 0x10, 0x1f, 0x00},
+	'ě': {0x0a, 0x04, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00},
+	'Ĝ': {0x04, 0x0a, 0x0e, 0x11, 0x10, 0x17, 0x0f, 0x00},
+	'ĝ': {0x04, 0x0a, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x00},
+	'Ğ': {0x11, 0x0e, 0x0e, 0x11, 0x10, 0x17, 0x0f, 0x00},
+	'ğ': {0x11, 0x0e, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x00},
+	'Ġ': {0x04, 0x00, 0x0e, 0x11, 0x10, 0x17, 0x0f, 0x00},
+	'ġ': {0x04, 0x00, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x00},
+	'Ģ': {0x0e, 0x11, 0x10, 0x17, 0x11, 0x0f, 0x04, 0x08},
+	'ģ': {0x00, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x04, 0x08},
+	'Ĥ': {0x04, 0x0a, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x00},
+	'ĥ': {0x04, 0x0a, 0x10, 0x10, 0x16, 0x19, 0x11, 0x00},
+	'Ĩ': {0x0d, 0x16, 0x0e, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'ĩ': {0x0d, 0x16, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Ī': {0x0e, 0x00, 0x0e, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'ī': {0x0e, 0x00, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Ĭ': {0x11, 0x0e, 0x0e, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'ĭ': {0x11, 0x0e, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Į': {0x0e, 0x04, 0x04, 0x04, 0x04, 0x0e, 0x02, 0x03},
+	'į': {0x00, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x02, 0x03},
+	'İ': {0x04, 0x00, 0x0e, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'ı': {0x00, 0x00, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Ĵ': {0x04, 0x0a, 0x07, 0x02, 0x02, 0x12, 0x0c, 0x00},
+	'ĵ': {0x04, 0x0a, 0x06, 0x02, 0x02, 0x12, 0x0c, 0x00},
+	'Ķ': {0x11, 0x14, 0x18, 0x14, 0x12, 0x11, 0x04, 0x08},
+	'ķ': {0x10, 0x12, 0x14, 0x18, 0x14, 0x12, 0x04, 0x08},
+	'Ĺ': {0x02, 0x04, 0x10, 0x10, 0x10, 0x10, 0x1f, 0x00},
+	'ĺ': {0x02, 0x04, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Ļ': {0x10, 0x10, 0x10, 0x10, 0x10, 0x1f, 0x04, 0x08},
+	'ļ': {0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e, 0x04, 0x08},
+	'Ľ': {0x0a, 0x04, 0x10, 0x10, 0x10, 0x10, 0x1f, 0x00},
+	'ľ': {0x0d, 0x05, 0x04, 0x04, 0x04, 0x04, 0x0e, 0x00},
+	'Ł': {0x10, 0x10, 0x14, 0x18, 0x10, 0x10, 0x1f, 0x00},
+	'ł': {0x0c, 0x04, 0x06, 0x0c, 0x04, 0x04, 0x0e, 0x00},
+	'Ń': {0x02, 0x04, 0x11, 0x19, 0x15, 0x13, 0x11, 0x00},
+	'ń': {0x02, 0x04, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00},
+	'Ņ': {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x04, 0x08},
+	'ņ': {0x00, 0x16, 0x19, 0x11, 0x11, 0x11, 0x04, 0x08},
+	'Ň': {0x0a, 0x04, 0x11, 0x19, 0x15, 0x13, 0x11, 0x00},
+	'ň': {0x0a, 0x04, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00},
+	'Ō': {0x0e, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ō': {0x0e, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ŏ': {0x11, 0x0e, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ŏ': {0x11, 0x0e, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Ő': {0x09, 0x12, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ő': {0x09, 0x12, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'Œ': {0x0f, 0x14, 0x14, 0x17, 0x14, 0x14, 0x0f, 0x00},
+	'œ': {0x00, 0x00, 0x0a, 0x15, 0x17, 0x14, 0x0b, 0x00},
+	'Ŕ': {0x02, 0x04, 0x1e, 0x11, 0x1e, 0x14, 0x11, 0x00},
+	'ŕ': {0x02, 0x04, 0x16, 0x19, 0x10, 0x10, 0x10, 0x00},
+	'Ŗ': {0x1e, 0x11, 0x1e, 0x14, 0x12, 0x11, 0x04, 0x08},
+	'ŗ': {0x00, 0x16, 0x19, 0x10, 0x10, 0x10, 0x04, 0x08},
+	'Ř': {0x0a, 0x04, 0x1e, 0x11, 0x1e, 0x14, 0x11, 0x00},
+	'ř': {0x0a, 0x04, 0x16, 0x19, 0x10, 0x10, 0x10, 0x00},
+	'Ś': {0x02, 0x04, 0x0f, 0x10, 0x0e, 0x01, 0x1e, 0x00},
+	'ś': {0x02, 0x04, 0x0e, 0x10, 0x0e, 0x01, 0x1e, 0x00},
+	'Ŝ': {0x04, 0x0a, 0x0f, 0x10, 0x0e, 0x01, 0x1e, 0x00},
+	'ŝ': {0x04, 0x0a, 0x0e, 0x10, 0x0e, 0x01, 0x1e, 0x00},
+	'Ş': {0x0f, 0x10, 0x10, 0x0e, 0x01, 0x1e, 0x04, 0x08},
+	'ş': {0x00, 0x0e, 0x10, 0x0e, 0x01, 0x1e, 0x04, 0x08},
+	'Š': {0x0a, 0x04, 0x0f, 0x10, 0x0e, 0x01, 0x1e, 0x00},
+	'š': {0x0a, 0x04, 0x0e, 0x10, 0x0e, 0x01, 0x1e, 0x00},
+	'Ţ': {0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x08},
+	'ţ': {0x08, 0x08, 0x1c, 0x08, 0x09, 0x06, 0x04, 0x08},
+	'Ť': {0x0a, 0x04, 0x1f, 0x04, 0x04, 0x04, 0x04, 0x00},
+	'ť': {0x09, 0x09, 0x1c, 0x08, 0x08, 0x09, 0x06, 0x00},
+	'Ũ': {0x0d, 0x16, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ũ': {0x0d, 0x16, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'Ū': {0x0e, 0x00, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ū': {0x0e, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'Ŭ': {0x11, 0x0e, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ŭ': {0x11, 0x0e, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'Ů': {0x0e, 0x0a, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ů': {0x0e, 0x0a, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'Ű': {0x09, 0x12, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00},
+	'ű': {0x09, 0x12, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00},
+	'Ų': {0x11, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x02, 0x03},
+	'ų': {0x00, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x02, 0x03},
+	'Ŵ': {0x04, 0x0a, 0x11, 0x11, 0x15, 0x15, 0x0a, 0x00},
+	'ŵ': {0x04, 0x0a, 0x11, 0x11, 0x15, 0x15, 0x0a, 0x00},
+	'Ŷ': {0x04, 0x0a, 0x11, 0x11, 0x0a, 0x04, 0x04, 0x00},
+	'ŷ': {0x04, 0x0a, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x00},
+	'Ÿ': {0x0a, 0x00, 0x11, 0x11, 0x0a, 0x04, 0x04, 0x00},
+	'Ź': {0x02, 0x04, 0x1f, 0x02, 0x04, 0x08, 0x1f, 0x00},
+	'ź': {0x02, 0x04, 0x1f, 0x02, 0x04, 0x08, 0x1f, 0x00},
+	'Ż': {0x04, 0x00, 0x1f, 0x02, 0x04, 0x08, 0x1f, 0x00},
+	'ż': {0x04, 0x00, 0x1f, 0x02, 0x04, 0x08, 0x1f, 0x00},
+	'Ž': {0x0a, 0x04, 0x1f, 0x02, 0x04, 0x08, 0x1f, 0x00},
+	'ž': {0x0a, 0x04, 0x1f, 0x02, 0x04, 0x08, 0x1f, 0x00},
+	'Ș': {0x0f, 0x10, 0x10, 0x0e, 0x01, 0x1e, 0x04, 0x08},
+	'ș': {0x00, 0x0e, 0x10, 0x0e, 0x01, 0x1e, 0x04, 0x08},
+	'Ț': {0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x08},
+	'ț': {0x08, 0x08, 0x1c, 0x08, 0x09, 0x06, 0x04, 0x08},
+}
+
+// SetGlyphFallback sets if letters missing from the character ROM (see SetROM) are drawn as
+// user-defined characters, from a 5x7 font of the Latin letters with diacritics of European
+// languages, e.g. "Å", "ę" and "ő", so that such text can be printed as is. The glyphs are loaded
+// into the CGRAM as they are printed, as registered glyphs are (see RegisterGlyph), so at most 8 such
+// letters (4 with 5x11 dot characters) can be shown at once, including registered glyphs; beyond
+// that, the least recently printed is replaced, changing what is shown. Letters of the ROM and runes
+// with a registered glyph are printed as they are. Takes precedence over SetTransliterate. Off by
+// default
+func (l *Device) SetGlyphFallback(on bool) {
+	l.do(func() {
+		l.glyphFallback = on
+	})
+}
+
+// fallbackGlyph returns the pattern of the rune if it is missing from the ROM and drawn from the
+// fallback font, see func SetGlyphFallback
+func (l *Device) fallbackGlyph(r rune) (Glyph, bool) {
+	if !l.glyphFallback {
+		return Glyph{}, false
+	}
+	g, ok := fallbackGlyphs[r]
+	if !ok || l.romCode(r) != '?' {
+		return Glyph{}, false
+	}
+	return g, true
+}
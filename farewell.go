@@ -0,0 +1,16 @@
+package st7066u
+
+import "time"
+
+// FarewellDelay is how long CloseWithMessage shows the message before turning the backlight off
+const FarewellDelay = time.Second * 2
+
+// CloseWithMessage shows the text, e.g. "Shutting down", word wrapped and centered, and closes the
+// device after FarewellDelay, turning the backlight off and releasing the GPIO. Unlike Close, the
+// text is left shown by the display for as long as it is powered
+func (l *Device) CloseWithMessage(text string) {
+	l.Update(func(f *Frame) { f.printMessage(text) })
+	l.Flush()
+	time.Sleep(FarewellDelay)
+	l.shutdown(false)
+}
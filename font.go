@@ -0,0 +1,198 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+// romFont is the pattern of each character of the character ROM, of the codes 0x20 - 0x7f and 0xa0 -
+// 0xff. The other codes are blank, apart from the user-defined characters
+var romFont = [256]Glyph{
+	0x21: {0x04, 0x04, 0x04, 0x04, 0x04, 0x00, 0x04, 0x00}, // !
+	0x22: {0x0a, 0x0a, 0x0a, 0x00, 0x00, 0x00, 0x00, 0x00}, // "
+	0x23: {0x0a, 0x0a, 0x1f, 0x0a, 0x1f, 0x0a, 0x0a, 0x00}, // #
+	0x24: {0x04, 0x0f, 0x14, 0x0e, 0x05, 0x1e, 0x04, 0x00}, // $
+	0x25: {0x18, 0x19, 0x02, 0x04, 0x08, 0x13, 0x03, 0x00}, // %
+	0x26: {0x0c, 0x12, 0x14, 0x08, 0x15, 0x12, 0x0d, 0x00}, // &
+	0x27: {0x0c, 0x04, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00}, // '
+	0x28: {0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02, 0x00}, // (
+	0x29: {0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08, 0x00}, // )
+	0x2a: {0x00, 0x04, 0x15, 0x0e, 0x15, 0x04, 0x00, 0x00}, // *
+	0x2b: {0x00, 0x04, 0x04, 0x1f, 0x04, 0x04, 0x00, 0x00}, // +
+	0x2c: {0x00, 0x00, 0x00, 0x00, 0x0c, 0x04, 0x08, 0x00}, // ,
+	0x2d: {0x00, 0x00, 0x00, 0x1f, 0x00, 0x00, 0x00, 0x00}, // -
+	0x2e: {0x00, 0x00, 0x00, 0x00, 0x00, 0x0c, 0x0c, 0x00}, // .
+	0x2f: {0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00, 0x00}, // /
+	0x30: {0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e, 0x00}, // 0
+	0x31: {0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e, 0x00}, // 1
+	0x32: {0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f, 0x00}, // 2
+	0x33: {0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e, 0x00}, // 3
+	0x34: {0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02, 0x00}, // 4
+	0x35: {0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e, 0x00}, // 5
+	0x36: {0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e, 0x00}, // 6
+	0x37: {0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08, 0x00}, // 7
+	0x38: {0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e, 0x00}, // 8
+	0x39: {0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c, 0x00}, // 9
+	0x3a: {0x00, 0x0c, 0x0c, 0x00, 0x0c, 0x0c, 0x00, 0x00}, // :
+	0x3b: {0x00, 0x0c, 0x0c, 0x00, 0x0c, 0x04, 0x08, 0x00}, // ;
+	0x3c: {0x02, 0x04, 0x08, 0x10, 0x08, 0x04, 0x02, 0x00}, // <
+	0x3d: {0x00, 0x00, 0x1f, 0x00, 0x1f, 0x00, 0x00, 0x00}, // =
+	0x3e: {0x08, 0x04, 0x02, 0x01, 0x02, 0x04, 0x08, 0x00}, // >
+	0x3f: {0x0e, 0x11, 0x01, 0x02, 0x04, 0x00, 0x04, 0x00}, // ?
+	0x40: {0x0e, 0x11, 0x01, 0x0d, 0x15, 0x15, 0x0e, 0x00}, // @
+	0x41: {0x0e, 0x11, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x00}, // A
+	0x42: {0x1e, 0x11, 0x11, 0x1e, 0x11, 0x11, 0x1e, 0x00}, // B
+	0x43: {0x0e, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0e, 0x00}, // C
+	0x44: {0x1c, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1c, 0x00}, // D
+	0x45: {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x1f, 0x00}, // E
+	0x46: {0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x10, 0x00}, // F
+	0x47: {0x0e, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0f, 0x00}, // G
+	0x48: {0x11, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x11, 0x00}, // H
+	0x49: {0x0e, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e, 0x00}, // I
+	0x4a: {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0c, 0x00}, // J
+	0x4b: {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11, 0x00}, // K
+	0x4c: {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1f, 0x00}, // L
+	0x4d: {0x11, 0x1b, 0x15, 0x15, 0x11, 0x11, 0x11, 0x00}, // M
+	0x4e: {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11, 0x00}, // N
+	0x4f: {0x0e, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00}, // O
+	0x50: {0x1e, 0x11, 0x11, 0x1e, 0x10, 0x10, 0x10, 0x00}, // P
+	0x51: {0x0e, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0d, 0x00}, // Q
+	0x52: {0x1e, 0x11, 0x11, 0x1e, 0x14, 0x12, 0x11, 0x00}, // R
+	0x53: {0x0f, 0x10, 0x10, 0x0e, 0x01, 0x01, 0x1e, 0x00}, // S
+	0x54: {0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x00}, // T
+	0x55: {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e, 0x00}, // U
+	0x56: {0x11, 0x11, 0x11, 0x11, 0x11, 0x0a, 0x04, 0x00}, // V
+	0x57: {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0a, 0x00}, // W
+	0x58: {0x11, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x11, 0x00}, // X
+	0x59: {0x11, 0x11, 0x11, 0x0a, 0x04, 0x04, 0x04, 0x00}, // Y
+	0x5a: {0x1f, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1f, 0x00}, // Z
+	0x5b: {0x0e, 0x08, 0x08, 0x08, 0x08, 0x08, 0x0e, 0x00}, // [
+	0x5c: {0x11, 0x0a, 0x1f, 0x04, 0x1f, 0x04, 0x04, 0x00}, // ¥
+	0x5d: {0x0e, 0x02, 0x02, 0x02, 0x02, 0x02, 0x0e, 0x00}, // ]
+	0x5e: {0x04, 0x0a, 0x11, 0x00, 0x00, 0x00, 0x00, 0x00}, // ^
+	0x5f: {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1f, 0x00}, // _
+	0x60: {0x08, 0x04, 0x02, 0x00, 0x00, 0x00, 0x00, 0x00}, // `
+	0x61: {0x00, 0x00, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00}, // a
+	0x62: {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x1e, 0x00}, // b
+	0x63: {0x00, 0x00, 0x0e, 0x10, 0x10, 0x11, 0x0e, 0x00}, // c
+	0x64: {0x01, 0x01, 0x0d, 0x13, 0x11, 0x11, 0x0f, 0x00}, // d
+	0x65: {0x00, 0x00, 0x0e, 0x11, 0x1f, 0x10, 0x0e, 0x00}, // e
+	0x66: {0x06, 0x09, 0x08, 0x1c, 0x08, 0x08, 0x08, 0x00}, // f
+	0x67: {0x00, 0x0f, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x00}, // g
+	0x68: {0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00}, // h
+	0x69: {0x04, 0x00, 0x0c, 0x04, 0x04, 0x04, 0x0e, 0x00}, // i
+	0x6a: {0x02, 0x00, 0x06, 0x02, 0x02, 0x12, 0x0c, 0x00}, // j
+	0x6b: {0x10, 0x10, 0x12, 0x14, 0x18, 0x14, 0x12, 0x00}, // k
+	0x6c: {0x0c, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e, 0x00}, // l
+	0x6d: {0x00, 0x00, 0x1a, 0x15, 0x15, 0x11, 0x11, 0x00}, // m
+	0x6e: {0x00, 0x00, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00}, // n
+	0x6f: {0x00, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00}, // o
+	0x70: {0x00, 0x00, 0x1e, 0x11, 0x1e, 0x10, 0x10, 0x00}, // p
+	0x71: {0x00, 0x00, 0x0d, 0x13, 0x0f, 0x01, 0x01, 0x00}, // q
+	0x72: {0x00, 0x00, 0x16, 0x19, 0x10, 0x10, 0x10, 0x00}, // r
+	0x73: {0x00, 0x00, 0x0e, 0x10, 0x0e, 0x01, 0x1e, 0x00}, // s
+	0x74: {0x08, 0x08, 0x1c, 0x08, 0x08, 0x09, 0x06, 0x00}, // t
+	0x75: {0x00, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00}, // u
+	0x76: {0x00, 0x00, 0x11, 0x11, 0x11, 0x0a, 0x04, 0x00}, // v
+	0x77: {0x00, 0x00, 0x11, 0x11, 0x15, 0x15, 0x0a, 0x00}, // w
+	0x78: {0x00, 0x00, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x00}, // x
+	0x79: {0x00, 0x00, 0x11, 0x11, 0x0f, 0x01, 0x0e, 0x00}, // y
+	0x7a: {0x00, 0x00, 0x1f, 0x02, 0x04, 0x08, 0x1f, 0x00}, // z
+	0x7b: {0x02, 0x04, 0x04, 0x08, 0x04, 0x04, 0x02, 0x00}, // {
+	0x7c: {0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x00}, // |
+	0x7d: {0x08, 0x04, 0x04, 0x02, 0x04, 0x04, 0x08, 0x00}, // }
+	0x7e: {0x00, 0x04, 0x02, 0x1f, 0x02, 0x04, 0x00, 0x00}, // →
+	0x7f: {0x00, 0x04, 0x08, 0x1f, 0x08, 0x04, 0x00, 0x00}, // ←
+	0xa1: {0x00, 0x00, 0x00, 0x00, 0x1c, 0x14, 0x1c, 0x00}, // ｡
+	0xa2: {0x07, 0x04, 0x04, 0x04, 0x00, 0x00, 0x00, 0x00}, // ｢
+	0xa3: {0x00, 0x00, 0x00, 0x04, 0x04, 0x04, 0x1c, 0x00}, // ｣
+	0xa4: {0x00, 0x00, 0x00, 0x00, 0x10, 0x08, 0x04, 0x00}, // ､
+	0xa5: {0x00, 0x00, 0x00, 0x0c, 0x0c, 0x00, 0x00, 0x00}, // ･
+	0xa6: {0x00, 0x1f, 0x01, 0x1f, 0x01, 0x02, 0x04, 0x00}, // ｦ
+	0xa7: {0x00, 0x00, 0x1f, 0x01, 0x06, 0x04, 0x08, 0x00}, // ｧ
+	0xa8: {0x00, 0x00, 0x02, 0x04, 0x0c, 0x14, 0x04, 0x00}, // ｨ
+	0xa9: {0x00, 0x00, 0x04, 0x1f, 0x11, 0x01, 0x06, 0x00}, // ｩ
+	0xaa: {0x00, 0x00, 0x00, 0x1f, 0x04, 0x04, 0x1f, 0x00}, // ｪ
+	0xab: {0x00, 0x00, 0x02, 0x1f, 0x06, 0x0a, 0x12, 0x00}, // ｫ
+	0xac: {0x00, 0x00, 0x08, 0x1f, 0x09, 0x0a, 0x08, 0x00}, // ｬ
+	0xad: {0x00, 0x00, 0x00, 0x0e, 0x02, 0x02, 0x1f, 0x00}, // ｭ
+	0xae: {0x00, 0x00, 0x1e, 0x02, 0x1e, 0x02, 0x1e, 0x00}, // ｮ
+	0xaf: {0x00, 0x00, 0x00, 0x15, 0x15, 0x01, 0x06, 0x00}, // ｯ
+	0xb0: {0x00, 0x00, 0x00, 0x1f, 0x00, 0x00, 0x00, 0x00}, // ｰ
+	0xb1: {0x1f, 0x01, 0x05, 0x06, 0x04, 0x04, 0x08, 0x00}, // ｱ
+	0xb2: {0x01, 0x02, 0x04, 0x0c, 0x14, 0x04, 0x04, 0x00}, // ｲ
+	0xb3: {0x04, 0x1f, 0x11, 0x11, 0x01, 0x02, 0x04, 0x00}, // ｳ
+	0xb4: {0x00, 0x1f, 0x04, 0x04, 0x04, 0x04, 0x1f, 0x00}, // ｴ
+	0xb5: {0x02, 0x1f, 0x02, 0x06, 0x0a, 0x12, 0x02, 0x00}, // ｵ
+	0xb6: {0x08, 0x1f, 0x09, 0x09, 0x09, 0x09, 0x12, 0x00}, // ｶ
+	0xb7: {0x04, 0x1f, 0x04, 0x1f, 0x04, 0x04, 0x04, 0x00}, // ｷ
+	0xb8: {0x00, 0x0f, 0x09, 0x11, 0x01, 0x02, 0x0c, 0x00}, // ｸ
+	0xb9: {0x08, 0x0f, 0x12, 0x02, 0x02, 0x02, 0x04, 0x00}, // ｹ
+	0xba: {0x00, 0x1f, 0x01, 0x01, 0x01, 0x01, 0x1f, 0x00}, // ｺ
+	0xbb: {0x0a, 0x1f, 0x0a, 0x0a, 0x02, 0x04, 0x08, 0x00}, // ｻ
+	0xbc: {0x00, 0x18, 0x01, 0x19, 0x01, 0x02, 0x1c, 0x00}, // ｼ
+	0xbd: {0x00, 0x1f, 0x01, 0x02, 0x04, 0x0a, 0x11, 0x00}, // ｽ
+	0xbe: {0x08, 0x1f, 0x09, 0x0a, 0x08, 0x08, 0x07, 0x00}, // ｾ
+	0xbf: {0x00, 0x11, 0x11, 0x09, 0x01, 0x02, 0x0c, 0x00}, // ｿ
+	0xc0: {0x00, 0x0f, 0x09, 0x17, 0x01, 0x02, 0x0c, 0x00}, // ﾀ
+	0xc1: {0x02, 0x1c, 0x04, 0x1f, 0x04, 0x04, 0x08, 0x00}, // ﾁ
+	0xc2: {0x00, 0x15, 0x15, 0x15, 0x01, 0x02, 0x04, 0x00}, // ﾂ
+	0xc3: {0x0e, 0x00, 0x1f, 0x04, 0x04, 0x04, 0x08, 0x00}, // ﾃ
+	0xc4: {0x08, 0x08, 0x08, 0x0c, 0x0a, 0x08, 0x08, 0x00}, // ﾄ
+	0xc5: {0x04, 0x04, 0x1f, 0x04, 0x04, 0x08, 0x10, 0x00}, // ﾅ
+	0xc6: {0x00, 0x0e, 0x00, 0x00, 0x00, 0x00, 0x1f, 0x00}, // ﾆ
+	0xc7: {0x00, 0x1f, 0x01, 0x0a, 0x04, 0x0a, 0x10, 0x00}, // ﾇ
+	0xc8: {0x04, 0x1f, 0x02, 0x04, 0x0e, 0x15, 0x04, 0x00}, // ﾈ
+	0xc9: {0x02, 0x02, 0x02, 0x02, 0x02, 0x04, 0x08, 0x00}, // ﾉ
+	0xca: {0x00, 0x04, 0x02, 0x11, 0x11, 0x11, 0x11, 0x00}, // ﾊ
+	0xcb: {0x10, 0x10, 0x1f, 0x10, 0x10, 0x10, 0x0f, 0x00}, // ﾋ
+	0xcc: {0x00, 0x1f, 0x01, 0x01, 0x01, 0x02, 0x0c, 0x00}, // ﾌ
+	0xcd: {0x00, 0x08, 0x14, 0x02, 0x01, 0x01, 0x00, 0x00}, // ﾍ
+	0xce: {0x04, 0x1f, 0x04, 0x04, 0x15, 0x15, 0x04, 0x00}, // ﾎ
+	0xcf: {0x00, 0x1f, 0x01, 0x01, 0x0a, 0x04, 0x02, 0x00}, // ﾏ
+	0xd0: {0x00, 0x0e, 0x00, 0x0e, 0x00, 0x0e, 0x01, 0x00}, // ﾐ
+	0xd1: {0x00, 0x04, 0x08, 0x10, 0x11, 0x1f, 0x01, 0x00}, // ﾑ
+	0xd2: {0x00, 0x01, 0x01, 0x0a, 0x04, 0x0a, 0x10, 0x00}, // ﾒ
+	0xd3: {0x00, 0x1f, 0x08, 0x1f, 0x08, 0x08, 0x07, 0x00}, // ﾓ
+	0xd4: {0x08, 0x08, 0x1f, 0x09, 0x0a, 0x08, 0x08, 0x00}, // ﾔ
+	0xd5: {0x00, 0x0e, 0x02, 0x02, 0x02, 0x02, 0x1f, 0x00}, // ﾕ
+	0xd6: {0x00, 0x1f, 0x01, 0x1f, 0x01, 0x01, 0x1f, 0x00}, // ﾖ
+	0xd7: {0x0e, 0x00, 0x1f, 0x01, 0x01, 0x02, 0x04, 0x00}, // ﾗ
+	0xd8: {0x12, 0x12, 0x12, 0x12, 0x01, 0x02, 0x04, 0x00}, // ﾘ
+	0xd9: {0x04, 0x14, 0x14, 0x15, 0x15, 0x16, 0x00, 0x00}, // ﾙ
+	0xda: {0x10, 0x10, 0x10, 0x11, 0x12, 0x14, 0x18, 0x00}, // ﾚ
+	0xdb: {0x00, 0x1f, 0x11, 0x11, 0x11, 0x11, 0x1f, 0x00}, // ﾛ
+	0xdc: {0x00, 0x1f, 0x11, 0x11, 0x01, 0x02, 0x04, 0x00}, // ﾜ
+	0xdd: {0x00, 0x18, 0x01, 0x01, 0x02, 0x04, 0x18, 0x00}, // ﾝ
+	0xde: {0x04, 0x12, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00}, // ﾞ
+	0xdf: {0x1c, 0x14, 0x1c, 0x00, 0x00, 0x00, 0x00, 0x00}, // ﾟ
+	0xe0: {0x00, 0x00, 0x09, 0x15, 0x12, 0x12, 0x0d, 0x00}, // α
+	0xe1: {0x0a, 0x00, 0x0e, 0x01, 0x0f, 0x11, 0x0f, 0x00}, // ä
+	0xe2: {0x00, 0x0e, 0x11, 0x1e, 0x11, 0x11, 0x1e, 0x10}, // ß
+	0xe3: {0x00, 0x00, 0x0e, 0x10, 0x0c, 0x11, 0x0e, 0x00}, // ε
+	0xe4: {0x00, 0x00, 0x11, 0x11, 0x13, 0x1a, 0x10, 0x10}, // μ
+	0xe5: {0x00, 0x00, 0x0f, 0x14, 0x11, 0x11, 0x0e, 0x00}, // σ
+	0xe6: {0x00, 0x00, 0x0e, 0x11, 0x11, 0x1e, 0x10, 0x10}, // ρ
+	0xe7: {0x00, 0x00, 0x0f, 0x11, 0x11, 0x0f, 0x01, 0x0e},
+	0xe8: {0x00, 0x00, 0x07, 0x04, 0x14, 0x08, 0x00, 0x00}, // √
+	0xe9: {0x00, 0x02, 0x1a, 0x02, 0x00, 0x00, 0x00, 0x00},
+	0xea: {0x00, 0x02, 0x00, 0x06, 0x02, 0x02, 0x12, 0x0c},
+	0xeb: {0x00, 0x14, 0x08, 0x14, 0x00, 0x00, 0x00, 0x00},
+	0xec: {0x04, 0x0e, 0x14, 0x15, 0x0e, 0x04, 0x00, 0x00}, // ¢
+	0xed: {0x08, 0x08, 0x1c, 0x08, 0x1c, 0x09, 0x16, 0x00}, // ₺
+	0xee: {0x0e, 0x00, 0x16, 0x19, 0x11, 0x11, 0x11, 0x00}, // ñ
+	0xef: {0x0a, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e, 0x00}, // ö
+	0xf0: {0x00, 0x00, 0x1e, 0x11, 0x11, 0x1e, 0x10, 0x10},
+	0xf1: {0x00, 0x00, 0x0f, 0x11, 0x11, 0x0f, 0x01, 0x01},
+	0xf2: {0x00, 0x0e, 0x11, 0x1f, 0x11, 0x11, 0x0e, 0x00}, // θ
+	0xf3: {0x00, 0x00, 0x00, 0x0b, 0x14, 0x1b, 0x00, 0x00}, // ∞
+	0xf4: {0x00, 0x0e, 0x11, 0x11, 0x0a, 0x1b, 0x00, 0x00}, // Ω
+	0xf5: {0x0a, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0d, 0x00}, // ü
+	0xf6: {0x1f, 0x10, 0x08, 0x04, 0x08, 0x10, 0x1f, 0x00}, // Σ
+	0xf7: {0x00, 0x00, 0x1f, 0x0a, 0x0a, 0x0a, 0x13, 0x00}, // Π
+	0xf8: {0x1f, 0x00, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x00},
+	0xf9: {0x00, 0x00, 0x11, 0x11, 0x11, 0x0f, 0x01, 0x0e},
+	0xfa: {0x00, 0x01, 0x1e, 0x04, 0x1f, 0x04, 0x04, 0x00},
+	0xfb: {0x00, 0x1f, 0x08, 0x0f, 0x09, 0x11, 0x03, 0x00}, // ㇲ
+	0xfc: {0x00, 0x1f, 0x15, 0x1f, 0x11, 0x11, 0x00, 0x00},
+	0xfd: {0x00, 0x04, 0x00, 0x1f, 0x00, 0x04, 0x00, 0x00}, // ÷
+	0xff: {0x1f, 0x1f, 0x1f, 0x1f, 0x1f, 0x1f, 0x1f, 0x1f}, // ⬛
+}
@@ -0,0 +1,371 @@
+package st7066u
+
+import (
+	"sort"
+	"strings"
+)
+
+// Frame is the staged content of the display, used by func Update. Changes made to a Frame are not
+// written to the display until the update is done
+type Frame struct {
+	rows  uint8
+	cols  uint8
+	cells [][]byte
+	row   uint8
+	col   uint8
+	l     *Device // The device of the frame, for registered glyphs, the first display of a Tiled display
+	tiled *Tiled  // The Tiled display of the frame, if any, with a device per tile, see func NewTiled
+}
+
+// Update stages the changes made by fn to a Frame holding the current content of the display, and
+// then writes only the characters that changed, as one burst. Intermediate states, e.g. a cleared
+// display before the new text is printed, are never visible, and the cursor is hidden during the
+// burst. fn is run while holding the device, so it must not call any methods of the Device, and the
+// Frame must not be used after fn returns, as it is reused by the next update. See also func
+// SetRateLimit
+func (l *Device) Update(fn func(f *Frame)) {
+	if l.lockSync() {
+		l.update(fn)
+		l.unlockSync()
+		return
+	}
+	l.do(func() { l.update(fn) })
+}
+
+// update stages the changes made by fn to a Frame and commits them, see func Update
+func (l *Device) update(fn func(f *Frame)) {
+	if l.closed {
+		return
+	}
+	f := l.frame()
+	fn(f)
+	l.commitLimited(f)
+	if l.target != f {
+		l.spare = f
+	}
+}
+
+// Clear clears the frame and moves the cursor to row 0, col 0
+func (f *Frame) Clear() {
+	for _, row := range f.cells {
+		for c := range row {
+			row[c] = 0x20
+		}
+	}
+	f.row, f.col = 0, 0
+}
+
+// ScrollUp moves the content of the frame up by one row, dropping the top row, clears the bottom row
+// and moves the cursor to its first column
+func (f *Frame) ScrollUp() {
+	top := f.cells[0]
+	copy(f.cells, f.cells[1:])
+	for c := range top {
+		top[c] = 0x20
+	}
+	f.cells[f.rows-1] = top
+	f.row, f.col = f.rows-1, 0
+	if f.tiled == nil {
+		return
+	}
+	for r := uint8(0); r+1 < f.rows; r++ {
+		for c := uint8(0); c < f.cols; c++ {
+			f.cells[r][c] = f.recode(f.cells[r][c], f.device(r+1, c), f.device(r, c))
+		}
+	}
+}
+
+// Print prints the text at the cursor of the frame, one character per rune as Device.Print does.
+// Text beyond the last column is dropped
+func (f *Frame) Print(text string) {
+	for _, r := range text {
+		f.PrintRune(r)
+	}
+}
+
+// PrintAt prints the text at the provided position
+func (f *Frame) PrintAt(row, col uint8, text string) {
+	f.SetCursor(row, col)
+	f.Print(text)
+}
+
+// PrintByte prints one byte character at the cursor of the frame
+func (f *Frame) PrintByte(ch byte) {
+	if f.col >= f.cols {
+		return
+	}
+	f.cells[f.row][f.col] = ch
+	f.col++
+}
+
+// PrintBytes prints the character codes as they are at the cursor of the frame, one per byte, see
+// Device.PrintBytes. Codes beyond the last column are dropped
+func (f *Frame) PrintBytes(codes []byte) {
+	for _, ch := range codes {
+		f.PrintByte(ch)
+	}
+}
+
+// PrintRune prints one rune character at the cursor of the frame
+func (f *Frame) PrintRune(ch rune) {
+	t := f.transliterate(ch, f.row, f.col)
+	if t == "" {
+		if f.col < f.cols {
+			f.PrintByte(f.encodeRune(ch, f.row, f.col))
+		}
+		return
+	}
+	for _, r := range t {
+		if f.col >= f.cols {
+			return
+		}
+		f.PrintByte(f.encodeRune(r, f.row, f.col))
+	}
+}
+
+// encodeRune returns the character code of the rune printed at the row and col, see
+// Device.encodeRune, or a space for cells not on any display of a Tiled display, without loading
+// glyphs for them
+func (f *Frame) encodeRune(r rune, row, col uint8) byte {
+	l := f.device(row, col)
+	if l == nil {
+		return 0x20
+	}
+	return l.encodeRune(r, f)
+}
+
+// transliterate returns the transliteration of the rune printed at the row and col, see
+// Device.transliterate
+func (f *Frame) transliterate(r rune, row, col uint8) string {
+	if l := f.device(row, col); l != nil {
+		return l.transliterate(r)
+	}
+	return f.l.transliterate(r)
+}
+
+// device returns the device of the cell at the row and col; the device of the frame, or the display
+// of a Tiled display the cell is on, nil if none
+func (f *Frame) device(row, col uint8) *Device {
+	if f.tiled == nil {
+		return f.l
+	}
+	for i := range f.tiled.rects {
+		if f.tiled.rects[i].contains(row, col) {
+			return f.tiled.tiles[i].Device
+		}
+	}
+	return nil
+}
+
+// eachDevice runs fn for the device of the frame, or for each display of a Tiled display
+func (f *Frame) eachDevice(fn func(l *Device)) {
+	if f.tiled == nil {
+		fn(f.l)
+		return
+	}
+	for _, tile := range f.tiled.tiles {
+		fn(tile.Device)
+	}
+}
+
+// recode returns the code on device to of the character code of device from, for a cell moved from
+// one display of a Tiled display to another; the code of the same rune on device to for a
+// user-defined character, as glyphs are loaded into each display, which drops any highlight, and the
+// code as is otherwise
+func (f *Frame) recode(code byte, from, to *Device) byte {
+	if from == nil || to == nil || from == to {
+		return code
+	}
+	if _, ok := from.codeSlot(code); !ok {
+		return code
+	}
+	return to.encodeRune(from.codeRune(code), f)
+}
+
+// SetCursor moves the cursor of the frame to the provided row and col, if within the display
+func (f *Frame) SetCursor(row, col uint8) {
+	if row >= f.rows || col >= f.cols {
+		return
+	}
+	f.row, f.col = row, col
+}
+
+// printField prints the text in a field of width cells at the row and col, left-aligned or
+// right-aligned and padded with spaces, leaving the cursor of the frame where it was. Text that
+// doesn't fit the field is truncated
+func (f *Frame) printField(row, col, width uint8, text string, right bool) {
+	if row >= f.rows || col >= f.cols {
+		return
+	}
+	t := []rune(text)
+	if len(t) > int(width) {
+		t = t[:width]
+	}
+	pad := strings.Repeat(" ", int(width)-len(t))
+	r, c := f.row, f.col
+	f.SetCursor(row, col)
+	if right {
+		f.Print(pad + string(t))
+	} else {
+		f.Print(string(t) + pad)
+	}
+	f.row, f.col = r, c
+}
+
+// clone returns a copy of the frame
+func (f *Frame) clone() *Frame {
+	c := *f
+	c.cells = make([][]byte, len(f.cells))
+	for r, row := range f.cells {
+		c.cells[r] = append([]byte(nil), row...)
+	}
+	return &c
+}
+
+// copyFrom sets the content and cursor of the frame to those of src
+func (f *Frame) copyFrom(src *Frame) {
+	for r, row := range src.cells {
+		copy(f.cells[r], row)
+	}
+	f.row, f.col = src.row, src.col
+}
+
+// frame returns a Frame holding the current content of the display, including any content not yet
+// written due to the frame budget
+func (l *Device) frame() *Frame {
+	f := l.spare
+	l.spare = nil
+	if f == nil {
+		f = &Frame{
+			rows:  l.rows,
+			cols:  l.cols,
+			cells: make([][]byte, l.rows),
+			l:     l,
+		}
+		for r := range f.cells {
+			f.cells[r] = make([]byte, l.cols)
+		}
+	}
+	if l.target != nil {
+		f.copyFrom(l.target)
+		return f
+	}
+	for r := range f.cells {
+		for c := range f.cells[r] {
+			f.cells[r][c] = l.cell(uint8(r), uint8(c))
+		}
+	}
+	f.row, f.col = 0, 0
+	if r, c, ok := l.cursor(); ok {
+		f.row, f.col = r, c
+	}
+	return f
+}
+
+// commit writes the characters of the frame that differ from the display, highest priority first
+// (see func SetPriority), and leaves the cursor where the frame has it. If the frame budget doesn't
+// allow all characters to be written, as many as it allows are, splitting a run if needed, and the
+// frame is held back to be written by the next frame, see func holdBudget
+func (l *Device) commit(f *Frame) {
+	runs := l.changedRuns(f)
+	display := l.displayCtl
+	if display&0b11 != 0 && len(runs) > 0 {
+		l.setDisplayBit(0b11, false)
+	}
+	l.target = nil
+	if len(runs) > 0 && !l.bounded {
+		l.updates++
+	}
+	spent := 0
+	for _, r := range runs {
+		ctl, addr := l.geo.cellAddr(r.row, r.col)
+		moved := l.ctl != ctl || l.addr[ctl] != addr
+		cost := len(r.cells)
+		if moved {
+			cost++
+		}
+		cells := r.cells
+		if l.budget > 0 && spent+cost > l.budget {
+			n := l.budget - spent
+			if moved {
+				n--
+			}
+			if n <= 0 && spent == 0 {
+				n = 1 // Progress is made by every frame, however small the budget
+			}
+			if n <= 0 {
+				l.holdBudget(f)
+				break
+			}
+			cells = cells[:n]
+			l.holdBudget(f)
+		}
+		spent += cost
+		if moved {
+			l.setAddr(ctl, addr)
+		}
+		for _, ch := range cells {
+			l.writeData(ch)
+		}
+		if l.target != nil {
+			break
+		}
+	}
+	var ctl int
+	var addr uint8
+	if f.col == f.cols {
+		ctl, addr = l.geo.cellAddr(f.row, f.col-1)
+		addr = nextAddr(addr, l.geo.twoLines, true)
+	} else {
+		ctl, addr = l.geo.cellAddr(f.row, f.col)
+	}
+	if l.ctl != ctl || l.addr[ctl] != addr {
+		l.setAddr(ctl, addr)
+	}
+	if display&0b11 != 0 && len(runs) > 0 {
+		l.setDisplayBit(display&0b11, true)
+	}
+}
+
+// run is a sequence of changed characters on one row, all with the same priority, that can be
+// written without moving the cursor in between
+type run struct {
+	row, col uint8
+	prio     int
+	cells    []byte
+}
+
+// changedRuns returns the characters of the frame that differ from the display, as runs sorted by
+// priority, highest first, and then by position
+func (l *Device) changedRuns(f *Frame) []run {
+	runs := l.runs[:0]
+	for r, row := range f.cells {
+		cur := -1 // Index of the run being extended, if any
+		for c, ch := range row {
+			if l.cell(uint8(r), uint8(c)) == ch {
+				cur = -1
+				continue
+			}
+			prio := l.priority(uint8(r), uint8(c))
+			if cur < 0 || runs[cur].prio != prio || !l.geo.follows(uint8(c)) {
+				var cells []byte
+				if len(runs) < cap(runs) {
+					cells = runs[:len(runs)+1][len(runs)].cells[:0] // Reused
+				}
+				runs = append(runs, run{row: uint8(r), col: uint8(c), prio: prio, cells: cells})
+				cur = len(runs) - 1
+			}
+			runs[cur].cells = append(runs[cur].cells, ch)
+		}
+	}
+	l.runs = runs
+	if len(l.regions) > 0 {
+		sort.SliceStable(runs, func(i, j int) bool { return runs[i].prio > runs[j].prio })
+	}
+	return runs
+}
+
+// cursor returns the row and col of the cursor, if it is within the visible part of the display
+func (l *Device) cursor() (row, col uint8, ok bool) {
+	return l.geo.cellAt(l.ctl, l.addr[l.ctl])
+}
@@ -0,0 +1,155 @@
+package st7066u
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// geometry maps the rows and columns of a display to the controller, and DDRAM address, showing them
+type geometry struct {
+	cols     uint8
+	offsets  []uint8 // DDRAM address of col 0 of each row
+	ctls     []int   // Controller of each row
+	split    uint8   // Col from which each row continues on the second line, 0 if not split
+	twoLines bool    // The controllers are set up for 2 lines
+}
+
+// profiles are the geometries of displays, by name, see func NewFromProfile
+var (
+	profilesMu sync.RWMutex
+	profiles   = map[string]geometry{
+		"0801": newGeometry(1, 8),
+		"1602": newGeometry(2, 16),
+		// 16x1, internally 8x2: col 8 - 15 are shown from the second line
+		"1601": {cols: 16, offsets: []uint8{0x00}, ctls: []int{0}, split: 8, twoLines: true},
+		// 20x4, where rows 2 and 3 continue lines 0 and 1 of the controller
+		"2004": {cols: 20, offsets: []uint8{0x00, 0x40, 0x14, 0x54}, ctls: []int{0, 0, 0, 0}, twoLines: true},
+		"4004": dualGeometry(),
+	}
+)
+
+// Profiles returns the names of all profiles, see NewFromProfile and RegisterProfile
+func Profiles() []string {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterProfile registers a profile for a display with one controller, with the nr of columns and
+// the DDRAM address of col 0 of each row, e.g. 0x00, 0x40, 0x14, 0x54 for a 20x4 display. Rows at
+// 0x40 or above are on the second line of the controller. An existing profile with the name is
+// replaced
+func RegisterProfile(name string, nrOfCols uint8, offsets ...uint8) error {
+	if len(offsets) < 1 || len(offsets) > 4 || nrOfCols < 1 || nrOfCols > 40 {
+		return errors.New("Number of rows must be 1 - 4, and number of columns must be greater than 0 and at most 40")
+	}
+	geo := geometry{
+		cols:    nrOfCols,
+		offsets: append([]uint8(nil), offsets...),
+		ctls:    make([]int, len(offsets)),
+	}
+	for _, o := range offsets {
+		geo.twoLines = geo.twoLines || o >= 0x40
+	}
+	length := 0x50
+	if geo.twoLines {
+		length = 0x28
+	}
+	for _, o := range offsets {
+		if int(o&^0x40)+int(nrOfCols) > length {
+			return errors.New("Row offsets must leave all columns within a line of the controller")
+		}
+	}
+	profilesMu.Lock()
+	profiles[name] = geo
+	profilesMu.Unlock()
+	return nil
+}
+
+// newGeometry returns the geometry of a display with one controller and 1 or 2 rows
+func newGeometry(rows, cols uint8) geometry {
+	return geometry{
+		cols:     cols,
+		offsets:  []uint8{0x00, 0x40}[:rows],
+		ctls:     make([]int, rows),
+		twoLines: rows == 2,
+	}
+}
+
+// dualGeometry returns the geometry of a 40x4 display with two controllers, each showing 2 rows
+func dualGeometry() geometry {
+	return geometry{
+		cols:     40,
+		offsets:  []uint8{0x00, 0x40, 0x00, 0x40},
+		ctls:     []int{0, 0, 1, 1},
+		twoLines: true,
+	}
+}
+
+// profileGeometry returns the geometry of the named profile, if valid with the font symmetry
+func profileGeometry(profile string, charSym uint8) (geometry, error) {
+	profilesMu.RLock()
+	geo, ok := profiles[profile]
+	profilesMu.RUnlock()
+	if !ok {
+		return geo, errors.New("Unknown profile " + profile)
+	}
+	if charSym > DOTS5x11 {
+		return geo, errors.New("Only 5x8 and 5x11 dot characters are supported")
+	}
+	if charSym == DOTS5x11 && geo.twoLines {
+		return geo, errors.New("5x11 dot characters not supported in multiline LCD displays")
+	}
+	return geo, nil
+}
+
+// controllers returns the nr of controllers of the display
+func (g geometry) controllers() int {
+	return g.ctls[len(g.ctls)-1] + 1
+}
+
+// cellAddr returns the controller and DDRAM address showing the row and col
+func (g geometry) cellAddr(row, col uint8) (int, uint8) {
+	if g.split > 0 && col >= g.split {
+		return g.ctls[row], g.offsets[row] + 0x40 + col - g.split
+	}
+	return g.ctls[row], g.offsets[row] + col
+}
+
+// cellAt returns the row and col shown at the DDRAM address of the controller, if any
+func (g geometry) cellAt(ctl int, addr uint8) (row, col uint8, ok bool) {
+	for r := range g.offsets {
+		for c := uint8(0); c < g.cols; c++ {
+			if a, b := g.cellAddr(uint8(r), c); a == ctl && b == addr {
+				return uint8(r), c, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// follows reports if the col is shown from the DDRAM address following that of the col before it,
+// i.e. if the cursor moves on to it by itself
+func (g geometry) follows(col uint8) bool {
+	return g.split == 0 || col != g.split
+}
+
+// jump returns the address the cursor must be moved to from addr, where the cursor moving on (or
+// back) by itself would leave the visible part of a split display
+func (g geometry) jump(addr uint8, increment bool) (uint8, bool) {
+	switch {
+	case g.split == 0:
+		return 0, false
+	case increment && addr == g.split:
+		return 0x40, true
+	case !increment && addr == 0x27:
+		return g.split - 1, true
+	}
+	return 0, false
+}
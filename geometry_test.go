@@ -0,0 +1,117 @@
+package st7066u
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var alphabet = strings.Repeat("ABCDEFGHIJKLMNOPQRSTUVWXYZ", 2)
+
+func TestGeometry(t *testing.T) {
+	tests := []struct {
+		profile    string
+		rows, cols uint8
+		wrapped    []string // Shown after printing 4 characters more than a row holds from row 0, col 0
+	}{
+		{"0801", 1, 8, []string{"ABCDEFGH"}},
+		{"1601", 1, 16, []string{"ABCDEFGHIJKLMNOP"}},
+		{"1602", 2, 16, []string{"ABCDEFGHIJKLMNOP", ""}},
+		{"2004", 4, 20, []string{"ABCDEFGHIJKLMNOPQRST", "", "UVWX", ""}},
+		{"4004", 4, 40, []string{alphabet[:40], "OPQR", "", ""}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.profile, func(t *testing.T) {
+			d, s := newTestDevice(t, tt.profile, BITMODE4)
+			if d.Rows() != tt.rows || d.Cols() != tt.cols {
+				t.Fatalf("Size %dx%d, want %dx%d", d.Cols(), d.Rows(), tt.cols, tt.rows)
+			}
+
+			t.Run("wrapping", func(t *testing.T) {
+				text := alphabet[:tt.cols+4]
+				d.Clear()
+				d.Print(text)
+				checkLines(t, s, tt.wrapped...)
+				checkShadow(t, d, s)
+			})
+
+			t.Run("SetCursor", func(t *testing.T) {
+				d.Clear()
+				want := make([]string, tt.rows)
+				for r := uint8(0); r < tt.rows; r++ {
+					for _, c := range []uint8{0, tt.cols/2 - 1, tt.cols / 2, tt.cols - 1} {
+						if err := d.SetCursor(r, c); err != nil {
+							t.Fatal(err)
+						}
+						d.PrintByte('0' + r)
+					}
+					line := []rune(strings.Repeat(" ", int(tt.cols)))
+					for _, c := range []uint8{0, tt.cols/2 - 1, tt.cols / 2, tt.cols - 1} {
+						line[c] = rune('0' + r)
+					}
+					want[r] = string(line)
+				}
+				checkLines(t, s, want...)
+				checkShadow(t, d, s)
+				if err := d.SetCursor(tt.rows, 0); err == nil {
+					t.Errorf("No error moving to row %d", tt.rows)
+				}
+				if err := d.SetCursor(0, tt.cols); err == nil {
+					t.Errorf("No error moving to col %d", tt.cols)
+				}
+			})
+
+			t.Run("scrolling", func(t *testing.T) {
+				d.Clear()
+				for r := uint8(0); r < tt.rows; r++ {
+					d.PrintAt(r, tt.cols-5, fmt.Sprintf("row %d", r))
+				}
+				d.ScrollUp()
+				d.Print("new")
+				want := make([]string, tt.rows)
+				for r := uint8(0); r+1 < tt.rows; r++ {
+					want[r] = strings.Repeat(" ", int(tt.cols)-5) + fmt.Sprintf("row %d", r+1)
+				}
+				want[tt.rows-1] = "new"
+				checkLines(t, s, want...)
+				checkShadow(t, d, s)
+			})
+
+			t.Run("Update diffs", func(t *testing.T) {
+				d.Clear()
+				for r := uint8(0); r < tt.rows; r++ {
+					d.PrintAt(r, 0, strings.Repeat("-", int(tt.cols)))
+				}
+				d.SetCursor(0, 0)
+				before := d.Written()
+				d.Update(func(f *Frame) {
+					for r := uint8(0); r < tt.rows; r++ {
+						f.PrintAt(r, 0, strings.Repeat("-", int(tt.cols)))
+					}
+					f.SetCursor(0, 0)
+				})
+				if n := d.Written() - before; n != 0 {
+					t.Errorf("%d bytes written by an update changing nothing", n)
+				}
+				want := make([]string, tt.rows)
+				d.Update(func(f *Frame) {
+					for r := uint8(0); r < tt.rows; r++ {
+						f.PrintAt(r, 0, strings.Repeat("-", int(tt.cols)))
+						f.PrintAt(r, tt.cols-1-r, "+")
+						line := []rune(strings.Repeat("-", int(tt.cols)))
+						line[tt.cols-1-r] = '+'
+						want[r] = string(line)
+					}
+					f.SetCursor(0, 0)
+				})
+				// The address and the character of each cell changed, and the cursor moved back
+				if n, max := d.Written()-before, uint64(tt.rows)*2+1; n > max {
+					t.Errorf("%d bytes written by an update changing %d cells, want at most %d", n, tt.rows, max)
+				}
+				checkLines(t, s, want...)
+				checkShadow(t, d, s)
+			})
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package st7066u
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Glyph is the pattern of a user-defined 5x8 character, one byte per row from the top, with the
+// lowest 5 bits of each byte being the dots from left to right, e.g. 0b00100 for the middle dot
+type Glyph [8]byte
+
+// glyphSlot is one of the user-defined characters of the CGRAM, holding a registered glyph or a
+// glyph loaded with LoadIcon
+type glyphSlot struct {
+	g       Glyph
+	r       rune
+	used    bool   // Holds the registered glyph of r, or its inverse
+	fixed   bool   // Holds a glyph loaded with LoadIcon
+	inverse bool   // Holds the inverse of the character r, see func Frame.Highlight
+	last    uint64 // When the glyph was last printed, see Device.glyphTick
+}
+
+// RegisterGlyph registers the pattern of a user-defined character for the rune, which may then be
+// printed as any other rune. The controller only holds 8 user-defined characters (4 with 5x11 dot
+// characters), so the glyphs are loaded into the CGRAM as they are printed, replacing the least
+// recently printed glyph that isn't shown. If all glyphs loaded are shown, the least recently
+// printed is replaced anyway, changing what is shown. Registering a rune that is already registered
+// replaces its pattern, also where it is shown. Registered glyphs take precedence over the ROM
+// characters
+func (l *Device) RegisterGlyph(r rune, g Glyph) {
+	l.do(func() {
+		if l.glyphs == nil {
+			l.glyphs = make(map[rune]Glyph)
+		}
+		l.glyphs[r] = g
+		for i, s := range l.slots {
+			if s.used && !s.inverse && s.r == r {
+				l.slots[i].g = g
+				l.writeGlyph(i)
+			}
+		}
+	})
+}
+
+// UnregisterGlyph removes the registered pattern of the rune. Where the glyph is shown, it is shown
+// until its user-defined character is replaced
+func (l *Device) UnregisterGlyph(r rune) {
+	l.do(func() {
+		delete(l.glyphs, r)
+		for i, s := range l.slots {
+			if s.used && !s.inverse && s.r == r {
+				l.slots[i] = glyphSlot{}
+			}
+		}
+	})
+}
+
+// LoadIcon loads the glyph into the user-defined character of the slot, 0 - 7 (0 - 3 with 5x11 dot
+// characters), where it stays until unloaded with UnloadIcon. It is printed as the rune of the slot,
+// e.g. "\x02" for slot 2, or with PrintByte. The slot isn't used for registered glyphs meanwhile (see
+// RegisterGlyph), and a registered glyph it held is replaced. See package icons for common icons
+func (l *Device) LoadIcon(g Glyph, slot uint8) error {
+	if int(slot) >= l.nrOfSlots() {
+		return fmt.Errorf("Slot %d is out of range", slot)
+	}
+	l.do(func() {
+		l.slots[slot] = glyphSlot{g: g, fixed: true}
+		l.writeGlyph(int(slot))
+	})
+	return nil
+}
+
+// UnloadIcon releases the slot of a glyph loaded with LoadIcon, to be used for registered glyphs
+// again. What is shown is left as is, until the slot is used again
+func (l *Device) UnloadIcon(slot uint8) {
+	l.do(func() {
+		if int(slot) < l.nrOfSlots() && l.slots[slot].fixed {
+			l.slots[slot] = glyphSlot{}
+		}
+	})
+}
+
+// encodeRune returns the character code of the rune; the code of a user-defined character for a
+// registered glyph or a letter of the fallback font, loading it if needed, the code of a slot loaded
+// with LoadIcon for its rune, or the ROM code otherwise. Codes shown in the frame f, if any, are not
+// replaced
+func (l *Device) encodeRune(r rune, f *Frame) byte {
+	if r >= 0 && int(r) < l.nrOfSlots() && l.slots[r].fixed {
+		return l.slotCode(int(r))
+	}
+	g, ok := l.glyphs[r]
+	if !ok {
+		if g, ok = l.fallbackGlyph(r); !ok {
+			return l.romCode(r)
+		}
+	}
+	l.glyphTick++
+	for i, s := range l.slots[:l.nrOfSlots()] {
+		if s.used && !s.inverse && s.r == r {
+			l.slots[i].last = l.glyphTick
+			return l.slotCode(i)
+		}
+	}
+	i := l.freeSlot(f)
+	if i < 0 {
+		return l.romCode(r)
+	}
+	l.slots[i] = glyphSlot{g: g, r: r, used: true, last: l.glyphTick}
+	l.writeGlyph(i)
+	return l.slotCode(i)
+}
+
+// freeSlot returns the slot to load a glyph into; an unused slot, or the least recently printed slot
+// that isn't shown, or the least recently printed slot. -1 is returned if all slots are loaded with
+// LoadIcon
+func (l *Device) freeSlot(f *Frame) int {
+	slots := l.slots[:l.nrOfSlots()]
+	var shown [8]bool
+	l.eachCode(f, func(code byte) {
+		if i, ok := l.codeSlot(code); ok {
+			shown[i] = true
+		}
+	})
+	lru, lruShown := -1, -1
+	for i, s := range slots {
+		if s.fixed {
+			continue
+		}
+		if !s.used {
+			return i
+		}
+		if !shown[i] && (lru < 0 || s.last < slots[lru].last) {
+			lru = i
+		}
+		if lruShown < 0 || s.last < slots[lruShown].last {
+			lruShown = i
+		}
+	}
+	if lru < 0 {
+		return lruShown
+	}
+	return lru
+}
+
+// eachCode calls fn with the code of each cell shown, or to be shown by the frame f (if any) or by
+// content not yet written due to the frame budget
+func (l *Device) eachCode(f *Frame, fn func(code byte)) {
+	for r := uint8(0); r < l.rows; r++ {
+		for c := uint8(0); c < l.cols; c++ {
+			fn(l.cell(r, c))
+		}
+	}
+	for _, f := range []*Frame{f, l.target} {
+		if f == nil {
+			continue
+		}
+		for _, row := range f.cells {
+			for _, code := range row {
+				fn(code)
+			}
+		}
+	}
+}
+
+// writeGlyph loads the glyph of the slot into the CGRAM of all controllers, leaving the cursor where
+// it was
+func (l *Device) writeGlyph(slot int) {
+	g := l.slots[slot].g
+	rows := 8
+	if l.sym == DOTS5x11 {
+		rows = 16
+	}
+	all := l.all
+	l.all = true
+	l.write(0x40|uint8(slot*rows), cmdInstruction)
+	for i := 0; i < rows; i++ {
+		var b byte
+		if i < len(g) {
+			b = g[i] & 0x1f
+		}
+		l.write(b, cmdData)
+	}
+	l.all = all
+	l.restoreAddrs(l.addr, l.ctl)
+}
+
+// writeGlyphs loads all glyphs into the CGRAM again, e.g. after the display has been reinitialized
+func (l *Device) writeGlyphs() {
+	for i, s := range l.slots[:l.nrOfSlots()] {
+		if s.used || s.fixed {
+			l.writeGlyph(i)
+		}
+	}
+}
+
+// nrOfSlots returns the nr of user-defined characters of the CGRAM
+func (l *Device) nrOfSlots() int {
+	if l.sym == DOTS5x11 {
+		return 4
+	}
+	return 8
+}
+
+// slotCode returns the character code of the user-defined character
+func (l *Device) slotCode(slot int) byte {
+	if l.sym == DOTS5x11 {
+		return byte(slot << 1)
+	}
+	return byte(slot)
+}
+
+// codeSlot returns the user-defined character shown for the character code, if any. The codes 0x00
+// - 0x07 and 0x08 - 0x0f show the same characters, and with 5x11 dot characters so do pairs of codes
+func (l *Device) codeSlot(code byte) (int, bool) {
+	if code > 0x0f {
+		return 0, false
+	}
+	if l.sym == DOTS5x11 {
+		return int(code&0x07) >> 1, true
+	}
+	return int(code & 0x07), true
+}
+
+// codeRune returns the rune shown for the character code; the rune of a registered glyph for a
+// user-defined character holding one, the rune of the slot for a glyph loaded with LoadIcon, and the
+// rune of the ROM code otherwise
+func (l *Device) codeRune(code byte) rune {
+	if i, ok := l.codeSlot(code); ok && l.slots[i].used {
+		return l.slots[i].r
+	} else if ok && l.slots[i].fixed {
+		return rune(i)
+	}
+	return l.romRune(code)
+}
+
+// ParseGlyph returns the glyph drawn by the rows, from the top, e.g.
+//
+//	ParseGlyph(
+//		"..X..",
+//		".XXX.",
+//		"XXXXX",
+//		...
+//	)
+//
+// Each row is 5 dots wide, with 'X', 'x', '#', '*' or '1' for a dot that is on, and '.', ' ', '_',
+// '-' or '0' for a dot that is off. 7 or 8 rows may be given, as the bottom row is the row of the
+// cursor which is often left blank
+func ParseGlyph(rows ...string) (Glyph, error) {
+	var g Glyph
+	if len(rows) < 7 || len(rows) > len(g) {
+		return g, fmt.Errorf("A glyph must have 7 or 8 rows, not %d", len(rows))
+	}
+	for i, row := range rows {
+		r := []rune(row)
+		if len(r) != 5 {
+			return Glyph{}, fmt.Errorf("Row %d of the glyph must be 5 dots wide, not %d", i+1, len(r))
+		}
+		for c, d := range r {
+			switch d {
+			case 'X', 'x', '#', '*', '1':
+				g[i] |= 1 << uint(4-c)
+			case '.', ' ', '_', '-', '0':
+			default:
+				return Glyph{}, fmt.Errorf("Row %d of the glyph has %q, which is neither a dot on nor off", i+1, d)
+			}
+		}
+	}
+	return g, nil
+}
+
+// ParseGlyphText returns the glyph drawn by the lines of the text, as for ParseGlyph, e.g. from a
+// text file embedded in the program. Empty lines before and after the glyph, and trailing carriage
+// returns, are ignored
+func ParseGlyphText(text string) (Glyph, error) {
+	lines := strings.Split(strings.Trim(strings.ReplaceAll(text, "\r", ""), "\n"), "\n")
+	return ParseGlyph(lines...)
+}
+
+// MustParseGlyph is as ParseGlyph, but panics if the rows don't draw a valid glyph. It simplifies
+// initialization of variables holding glyphs
+func MustParseGlyph(rows ...string) Glyph {
+	g, err := ParseGlyph(rows...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
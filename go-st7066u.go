@@ -2,9 +2,10 @@ package st7066u
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
+	"sync"
 	"time"
-
-	"github.com/stianeikeland/go-rpio"
 )
 
 // BITMODE4 and BITMODE8; used to denote if the LCD display is used in 4 or 8 bit mode respectively
@@ -27,208 +28,719 @@ const (
 const (
 	pinEDelay = time.Microsecond * 1
 	pinEWait  = time.Microsecond * 70
+	execWait  = time.Microsecond * 1520 // Execution time of the clear display and return home instructions
+	powerWait = time.Millisecond * 40   // From power on until the display accepts instructions
+	initWait1 = time.Microsecond * 4100 // After the first function set during initialization
+	initWait2 = time.Microsecond * 100  // After the second function set during initialization
 	row1Addr  = 0x80
 	row2Addr  = 0xC0
 )
 
+// Pin is an output line connected to the LCD display. Implemented by rpio.Pin (machine pins with
+// TinyGo) and by the pins of the Simulator, and by the caller for displays wired through something
+// else than the GPIO pins of the Pi, e.g. a port expander or a pin of another framework. See func
+// NewFromPins
+type Pin interface {
+	High()
+	Low()
+	Output()
+}
+
+// noPin is a pin not connected to anything, used for an L pin given as NoPin
+type noPin struct{}
+
+func (noPin) High()   {}
+func (noPin) Low()    {}
+func (noPin) Output() {}
+
 // Device is the basic struct representing the LCD display. Use func New to get a new struct
 type Device struct {
 	rows              uint8
 	cols              uint8
-	pinRS, pinE, pinL rpio.Pin
-	pinDs             []rpio.Pin
+	pinRS, pinE, pinL Pin
+	pinE2             Pin // E pin of the second controller, if any
+	pinRW             Pin // R/W pin, if used, see func SetRWPin
+	pinDs             []Pin
+	rpio              bool // If the rpio memory mapping is opened, and closed, by the device
 	mode              uint8
 	sym               uint8
 	ledOn             bool
-	masks             map[string]uint8
-}
-
-// New returns a Device struct used as a handler for the LCD display. Arguments are
-//	nrOfRows:	(uint8) 1 or 2 rows LCD displayes are supported
-//	nrOfCols:	(uint8) Nr of columns in the display. 16 and 20 are common values
-//	charSym:	Symmetry of the characters on the LCD display. DOTS5x8 or DOTS5x11 are supported
-//	mode:		In which "mode" the display is connected (w/ 4 or 8 data wires). BITMODE4 and BITMODE8 are supported
-//	pinRS:		GPIO pin used for the RS (reset) pin on the LCD display
-//	pinE:		GPIO pin used for the E (enable) pin on the LCD display
-//	pinL:		GPIO pin used for the L (LED) pin on the LCD display
-//	pins:		GPIO pins used for data, can be either 4 or 8 pins. Start with the lowest numbered pin on the LCD display (D0 or D4, depending on "mode" used)
-func New(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, pinRS, pinE, pinL rpio.Pin, pins ...rpio.Pin) (*Device, error) {
+	backlightLow      bool // The backlight is on when the L pin is low, see func SetBacklightActiveLow
+	pwmOn             bool
+	brightness        float64
+	timing            Timing        // Timing of the writes, see func SetTiming
+	entryMode         uint8         // Entry mode set instruction
+	displayCtl        uint8         // Display on/off control instruction
+	functionSet       uint8         // Function set instruction
+	mu                sync.Mutex    // Serializes access to the display
+	qmu               sync.RWMutex  // Guards queue and worker
+	queue             chan func()   // Pending operations in async mode, nil otherwise
+	worker            chan struct{} // Closed when the worker of the async mode has stopped
+	geo               geometry
+	ddram             [2][0x80]byte     // What has been written to the display data RAM of each controller
+	addr              [2]uint8          // Current DDRAM address of each controller
+	ctl               int               // The controller written to, showing the cursor
+	all               bool              // Instructions are written to all controllers
+	rec               *PinRecording     // Recording of the pins, if any
+	cmdRec            *CommandRecording // Recording of the bytes written, if any
+	trace             *tracer           // Decodes the bytes written as text, if set
+	instructions      uint64            // Nr of instructions written, see func Stats
+	clears            uint64            // Nr of times the display has been cleared
+	updates           uint64            // Nr of updates that wrote changes to the display
+	busErrors         uint64            // Nr of writes and reads of the display that failed
+	writeTime         time.Duration     // Total time spent writing bytes
+	hooks             WriteHooks
+	minInterval       time.Duration // Min time between writes of updates, see func SetRateLimit
+	debounce          time.Duration // Time the changes of an update are held back to be coalesced
+	rateTimer         *time.Timer   // Writes the changes held back, if any
+	lastCommit        time.Time     // When an update was last written under the rate limit
+	bank              *gpioBank     // Memory mapped GPIO registers for the data pins, if available
+	bus               *Bus          // The bus shared with other displays, if any
+	i2c               *i2cLCD       // The controller, if written to over I2C instead of GPIO pins
+	backend           string        // Name of what the device writes to, see func Diagnostics
+	opened            time.Time
+	written           uint64 // Nr of bytes written to the display
+	writtenBefore     uint64 // Nr of bytes written by earlier runs, see func SetStore
+	store             Store  // Where settings and counters are persisted, if any
+	variant           Variant
+	rom               ROM             // Character ROM, see func SetROM
+	clampCursor       bool            // Positions outside the display are clamped, see func SetClampCursor
+	insert            bool            // Printed characters are inserted, see func SetInsertMode
+	translit          bool            // Runes missing from the ROM are transliterated, see func SetTransliterate
+	runeMap           map[rune]uint8  // Codes of runes set by the application, see func MapRune
+	glyphFallback     bool            // Letters missing from the ROM are drawn, see func SetGlyphFallback
+	contrast          float64         // Contrast, 0.0 - 1.0, see func SetContrast
+	contrastCh        contrastChannel // Source of the contrast voltage, if any
+	rgb               rgbChannel      // RGB backlight, if any
+	color             Color           // Color of an RGB backlight, see func SetBacklightColor
+	glyphs            map[rune]Glyph  // Registered user-defined characters, see func RegisterGlyph
+	slots             [8]glyphSlot    // The glyphs loaded into the CGRAM
+	glyphTick         uint64          // Nr of registered glyphs printed, ordering the slots by use
+	bounded           bool            // Bounded memory mode, see func SetBoundedMemory
+	regions           []region
+	budget            int           // Max nr of bytes written per Update, 0 for no limit
+	target            *Frame        // Content not yet written due to the budget, if any
+	spare             *Frame        // Frame of the last update, reused by the next one
+	runs              []run         // Runs of the last update, reused by the next one
+	watchdog          chan struct{} // Closed to stop the watchdog, if any
+	watchdogDone      chan struct{} // Closed when the watchdog has stopped
+	mirror            func(row uint8, text string)
+	mirrored          []string       // Text of the rows last passed to mirror
+	nmu               sync.Mutex     // Guards notes, notifying, notifyBlink, notifyStop and notifyDone
+	notes             []notification // Notifications waiting to be shown, see func Notify
+	notifying         bool           // A goroutine is showing the notifications
+	notifyBlink       bool           // The backlight is blinked when a notification is shown
+	notifyStop        chan struct{}  // Closed to stop the goroutine showing the notifications
+	notifyDone        chan struct{}  // Closed when the goroutine showing the notifications has ended
+	closed            bool           // The device is closed, and updates and notifications do nothing
+	idleTimeout       time.Duration  // See func SetIdleTimeout, 0 if off
+	idleDisplay       bool           // The display is turned off as well when idle
+	idle              bool           // The backlight (and display) is off due to inactivity
+	idleTimer         *time.Timer
+	lastActive        time.Time
+	tees              []*Device // Displays written to as well, see func Tee
+	teeHeld           bool      // Writes aren't written to the tee targets, e.g. those probing the controller
+	feedCG            bool      // Data written by the display teeing to this one goes to the CGRAM
+	feedExt           bool      // The display teeing to this one has selected an extended instruction set
+}
+
+// NewFromPins returns a Device as New does, but writing through the provided pins. pinL may be nil
+// if the backlight isn't controlled through a pin. The pins must be comparable, e.g. structs of
+// comparable fields or pointers
+func NewFromPins(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, pinRS, pinE, pinL Pin, pins ...Pin) (*Device, error) {
 	if err := validateSymm(nrOfRows, nrOfCols, charSym); err != nil {
 		return nil, err
 	}
-	if err := rpio.Open(); err != nil {
+	if err := validatePinMode(mode, len(pins)); err != nil {
+		return nil, err
+	}
+	ds := append([]Pin(nil), pins...)
+	claim := append([]Pin{pinRS, pinE}, ds...)
+	var l Pin = noPin{}
+	if pinL != nil {
+		l = pinL
+		claim = append(claim, pinL)
+	}
+	g := newDevice(newGeometry(nrOfRows, nrOfCols), charSym, pinRS, pinE, l, ds)
+	g.backend = "pins"
+	if err := claimPins(g, claim...); err != nil {
 		return nil, err
 	}
+	g.setDefaultMasks()
+	g.start()
+	return g, nil
+}
+
+// newDevice returns a Device with the geometry, using the provided pins. The mode is given by the
+// nr of data pins
+func newDevice(geo geometry, charSym uint8, pinRS, pinE, pinL Pin, pins []Pin) *Device {
 	g := &Device{
-		rows:  nrOfRows,
-		cols:  nrOfCols,
+		rows:  uint8(len(geo.offsets)),
+		cols:  geo.cols,
 		pinRS: pinRS,
 		pinE:  pinE,
 		pinL:  pinL,
 		pinDs: pins,
 		mode:  BITMODE8,
 		sym:   charSym,
-	}
-	if err := validatePinMode(mode, len(pins)); err != nil {
-		rpio.Close()
-		return nil, err
+		geo:   geo,
+
+		brightness: 1,
+		contrast:   0.5,
+		timing:     DefaultTiming,
+		color:      White,
+		backend:    "gpio",
+		opened:     time.Now(),
 	}
 	if len(pins) == 4 {
 		g.mode = BITMODE4
 	}
-	g.setDefaultMasks()
-	g.init()
-	g.Clear()
-	return g, nil
+	return g
 }
 
-// Clear clears the LCD
-func (l *Device) Clear() {
-	l.write(1<<0, cmdInstruction)
-	time.Sleep(pinEWait * 100)
+// Clear clears the LCD, and waits for the controller to finish, see Home
+func (l *Device) Clear() error {
+	var err error
+	l.doWait(func() { err = l.clear() })
+	return err
 }
 
-// Close closes the LCD display
+// Close closes the LCD display. In async mode, queued operations are written before closing.
+// Notifications queued or shown are dropped, and updates and notifications do nothing once closed
 func (l *Device) Close() {
-	l.Clear()
-	l.TurnOn(false)
-	l.LedOn(false)
+	l.shutdown(true)
+}
 
-	for _, p := range append(l.pinDs, l.pinRS, l.pinE) {
+// shutdown closes the display, clearing it and turning it off if clear is true, and leaving what is
+// shown otherwise. The backlight is turned off either way
+func (l *Device) shutdown(clear bool) {
+	l.SetWatchdog(0)
+	l.stopWorker()
+	l.mu.Lock()
+	closed := l.closed
+	l.closed = true
+	l.mu.Unlock()
+	if closed {
+		return
+	}
+	l.stopNotifications()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stopIdleTimer()
+	l.stopRateTimer()
+	if clear {
+		l.clear()
+		l.setDisplayBit(1<<2, false)
+	}
+	l.ledOn = false
+	l.applyBacklight()
+	if l.contrastCh != nil {
+		l.contrastCh.close()
+	}
+	if l.rgb != nil {
+		l.rgb.close()
+	}
+	if l.i2c != nil {
+		l.i2c.close()
+	}
+	if l.store != nil {
+		l.store.Save(keyWritten, []byte(strconv.FormatUint(l.writtenBefore+l.written, 10)))
+	}
+	pins := append(l.pinDs, l.pinRS, l.pinE)
+	if l.bus != nil {
+		pins = []Pin{l.pinE} // The other pins are still used by the other displays on the bus
+	}
+	if l.pinE2 != nil {
+		pins = append(pins, l.pinE2)
+	}
+	if l.pinRW != nil {
+		pins = append(pins, l.pinRW)
+	}
+	for _, p := range pins {
 		p.Low()
 	}
-	rpio.Close()
+	releasePins(l)
+	l.closeGPIO()
 }
 
 // CursorBlink sets the cursor to blink/not blink
 func (l *Device) CursorBlink(on bool) {
-	var mask uint8 = 1 << 0
-	if !on {
-		mask = ^mask
-		l.masks["display"] &= mask
-	} else {
-		l.masks["display"] |= mask
-	}
-	l.write(l.masks["display"], cmdInstruction)
+	l.do(func() { l.setDisplayBit(1<<0, on) })
 }
 
 // CursorOn shows/hides the cursor
 func (l *Device) CursorOn(on bool) {
-	var mask uint8 = 1 << 1
-	if !on {
-		mask = ^mask
-		l.masks["display"] &= mask
-	} else {
-		l.masks["display"] |= mask
-	}
-	l.write(l.masks["display"], cmdInstruction)
+	l.do(func() { l.setDisplayBit(1<<1, on) })
 }
 
-// Home moves the cursor to the home position, i.e. row 0, col 0
-func (l *Device) Home() {
-	l.write(1<<1, cmdInstruction)
+// Home moves the cursor to the home position, i.e. row 0, col 0, and waits for the controller to
+// finish, which takes 1.52 ms (3.04 ms on the SPLC780D). With the R/W pin (see SetRWPin), the busy
+// flag is polled instead, and an error is returned if the controller is still busy after 10 times as
+// long, e.g. if the display is disconnected. Unlike most calls, Home and Clear wait for the display also in async mode
+func (l *Device) Home() error {
+	var err error
+	l.doWait(func() { err = l.home() })
+	return err
 }
 
 // LedOn turns LCD LED on or off
 func (l *Device) LedOn(on bool) {
-	if on {
-		l.pinL.High()
-	} else {
-		l.pinL.Low()
-	}
+	l.do(func() {
+		l.ledOn = on
+		l.applyBacklight()
+	})
 }
 
 // MoveLeft moves the caret 'steps' steps to the left
 func (l *Device) MoveLeft(steps uint8) {
-	var mask uint8 = 0b10000
-	var a uint8
-	for a = 0; a < steps; a++ {
-		l.write(mask, cmdInstruction)
-	}
+	l.do(func() {
+		var mask uint8 = 0b10000
+		var a uint8
+		for a = 0; a < steps; a++ {
+			l.write(mask, cmdInstruction)
+			l.addr[l.ctl] = nextAddr(l.addr[l.ctl], l.geo.twoLines, false)
+			if addr, ok := l.geo.jump(l.addr[l.ctl], false); ok {
+				l.setAddr(l.ctl, addr)
+			}
+		}
+	})
 }
 
-// Print prints the provided text on the LCD display at the current position of the caret
+// Print prints the provided text on the LCD display at the current position of the caret. The text
+// is decoded as UTF-8, and each rune is printed as one character, the character of the ROM it is
+// mapped to, '?' if none, or a registered glyph (see RegisterGlyph). Invalid UTF-8 is printed as
+// '?', one per invalid byte. Use PrintBytes to print character codes as they are
 func (l *Device) Print(text string) {
-	txt := strToSt70660b(text)
-	for _, c := range txt {
-		l.write(c, cmdData)
+	if l.lockSync() {
+		l.touch()
+		l.print(text)
+		l.unlockSync()
+		return
 	}
+	l.do(func() {
+		l.touch()
+		l.print(text)
+	})
 }
 
 // PrintAt prints the provided text at the specified cursor position
 func (l *Device) PrintAt(row, col uint8, text string) {
-	l.SetCursor(row, col)
-	l.Print(text)
+	if l.lockSync() {
+		l.printAt(row, col, text)
+		l.unlockSync()
+		return
+	}
+	l.do(func() { l.printAt(row, col, text) })
 }
 
 // PrintByte prints just one byte character to the LCD display
 func (l *Device) PrintByte(ch byte) {
-	l.write(ch, cmdData)
+	if l.lockSync() {
+		l.touch()
+		l.printCode(ch)
+		l.unlockSync()
+		return
+	}
+	l.do(func() {
+		l.touch()
+		l.printCode(ch)
+	})
+}
+
+// PrintBytes prints the character codes as they are, one per byte, without decoding them as UTF-8
+// as Print does, e.g. to print codes of the ROM that no rune is mapped to
+func (l *Device) PrintBytes(codes []byte) {
+	l.do(func() {
+		l.touch()
+		for _, ch := range codes {
+			l.printCode(ch)
+		}
+	})
 }
 
 // PrintRune prints just one rune character to the LCD display
 func (l *Device) PrintRune(ch rune) {
-	l.write(runeToSt70660b(ch), cmdData)
+	if l.lockSync() {
+		l.touch()
+		l.printRune(ch)
+		l.unlockSync()
+		return
+	}
+	l.do(func() {
+		l.touch()
+		l.printRune(ch)
+	})
 }
 
-// SetCursor moves the cursor to the provided row and col
-func (l *Device) SetCursor(row, col uint8) {
-	if row > l.rows-1 || col > l.cols-1 {
-		return
+// ScrollUp moves what is shown up by one row, dropping the top row, clears the bottom row and moves
+// the cursor to its first column, e.g. for terminal-like output on a 20x4 display. Only the
+// characters that changed are written, without clearing the display
+func (l *Device) ScrollUp() {
+	l.Update(func(f *Frame) { f.ScrollUp() })
+}
+
+// SetCursor moves the cursor to the provided row and col. An error is returned if the position is
+// outside the display, leaving the cursor where it was, unless the cursor is clamped, see
+// SetClampCursor
+func (l *Device) SetCursor(row, col uint8) error {
+	if l.lockSync() {
+		err := l.checkCursor(row, col)
+		if err == nil {
+			l.setCursor(row, col)
+		}
+		l.unlockSync()
+		return err
+	}
+	l.mu.Lock()
+	err := l.checkCursor(row, col)
+	l.mu.Unlock()
+	if err != nil {
+		return err
 	}
-	offset := 0x40*row + col
-	l.write(0x80|offset, cmdInstruction)
+	l.do(func() { l.setCursor(row, col) })
+	return nil
+}
+
+// SetClampCursor sets if positions outside the display are clamped to the nearest cell of the
+// display, by SetCursor and PrintAt, instead of being refused by SetCursor and ignored by PrintAt.
+// Off by default
+func (l *Device) SetClampCursor(on bool) {
+	l.mu.Lock()
+	l.clampCursor = on
+	l.mu.Unlock()
+}
+
+// checkCursor returns an error if the position is outside the display and not clamped
+func (l *Device) checkCursor(row, col uint8) error {
+	if !l.clampCursor && (row >= l.rows || col >= l.cols) {
+		return fmt.Errorf("Position %d, %d is outside the %dx%d display", row, col, l.rows, l.cols)
+	}
+	return nil
 }
 
 // TurnOn is used to turn whole LCD display on or off
 func (l *Device) TurnOn(on bool) {
-	var mask uint8 = 1 << 2
-	if on {
-		l.masks["display"] |= mask
-	} else {
-		mask := ^mask
-		l.masks["display"] &= mask
+	l.do(func() { l.setDisplayBit(1<<2, on) })
+}
+
+// clear clears the LCD and waits for the display to finish
+func (l *Device) clear() error {
+	if !l.bounded {
+		l.clears++
 	}
-	l.write(l.masks["display"], cmdInstruction)
+	l.writeAll(1 << 0)
+	err := l.waitExec()
+	for c := range l.ddram {
+		for i := range l.ddram[c] {
+			l.ddram[c][i] = 0x20
+		}
+	}
+	l.addr = [2]uint8{}
+	l.selectCtl(0)
+	l.target = nil
+	return err
 }
 
 // enableWrite is the toggle sequence on pinE used to shift in the command
-// to the LCD display
+// to the LCD display. With two controllers, the E pin of the selected controller is toggled, or
+// both when writing to all controllers
 func (l *Device) enableWrite() {
-	time.Sleep(pinEDelay)
-	l.pinE.High()
-	time.Sleep(pinEDelay)
-	l.pinE.Low()
-	time.Sleep(pinEWait)
+	e1, e2 := l.pinE, l.pinE2
+	switch {
+	case e2 == nil || l.all:
+	case l.ctl == 0:
+		e2 = nil
+	default:
+		e1, e2 = e2, nil
+	}
+	time.Sleep(l.timing.EPulse)
+	e1.High()
+	if e2 != nil {
+		e2.High()
+	}
+	time.Sleep(l.timing.EPulse)
+	e1.Low()
+	if e2 != nil {
+		e2.Low()
+	}
+	time.Sleep(l.timing.EWait)
 }
 
-// init initializes the LCD display with the default values
+// init initializes the LCD display with the default values. The initialization by instruction of
+// the datasheet is used, which gets the controller into 8-bit mode from any state (also after a warm
+// restart, when it may be in 4-bit mode waiting for the second nibble) before the mode is set
 func (l *Device) init() {
-	for _, p := range append(l.pinDs, l.pinRS, l.pinE) {
-		rpio.PinMode(p, rpio.Output)
+	pins := append(l.pinDs, l.pinRS, l.pinE)
+	if l.pinE2 != nil {
+		pins = append(pins, l.pinE2)
+	}
+	for _, p := range pins {
+		p.Output()
 	}
-	l.write(l.masks["functionSet"], cmdInstruction)
+	time.Sleep(powerWait)
+	l.ctl, l.all = 0, true
+	l.setMode()
+	l.write(l.displayCtl&^(1<<2), cmdInstruction)
 	time.Sleep(pinEWait)
-	l.write(l.masks["display"], cmdInstruction)
+	l.clear()
+	l.write(l.entryMode, cmdInstruction)
+	time.Sleep(pinEWait)
+	l.all = false
+	l.writeDisplay()
+	time.Sleep(pinEWait)
+}
+
+// setMode gets the controller into 8-bit mode from any state, by writing the function set
+// instruction three times, and then sets the mode, nr of lines and font
+func (l *Device) setMode() {
+	for _, wait := range l.initWaits() {
+		l.writeInit(0x30)
+		time.Sleep(wait)
+	}
+	if l.mode == BITMODE4 {
+		l.writeInit(0x20)
+		time.Sleep(pinEWait)
+	}
+	l.write(l.functionSet, cmdInstruction)
 	time.Sleep(pinEWait)
+	l.initVariant()
+}
+
+// writeInit writes a function set instruction while the controller is in 8-bit mode, i.e. during
+// initialization. In 4-bit mode only the high nibble is written, as the controller only reads D4-D7
+func (l *Device) writeInit(data uint8) {
+	if l.mode == BITMODE8 {
+		l.write(data, cmdInstruction)
+		return
+	}
+	if l.trace != nil {
+		l.traceWrite(data, cmdInstruction, l.writtenCtl())
+	}
+	l.writeNibble(data>>4, cmdInstruction)
+}
+
+// writeNibble writes the lowest 4 bits of data to D4-D7 in 4-bit mode, with one toggle of pinE
+func (l *Device) writeNibble(data uint8, cmd uint8) {
+	if l.bus != nil {
+		l.bus.mu.Lock()
+		defer l.bus.mu.Unlock()
+	}
+	if cmd == cmdData {
+		l.pinRS.High()
+	} else {
+		l.pinRS.Low()
+	}
+	l.setData(data)
+	l.enableWrite()
+}
+
+// printAt writes the text at the row and col
+func (l *Device) printAt(row, col uint8, text string) {
+	l.touch()
+	l.setCursor(row, col)
+	l.print(text)
+}
+
+// print writes the text at the current position of the caret
+func (l *Device) print(text string) {
+	for _, r := range text {
+		l.printRune(r)
+	}
+}
+
+// printRune writes the rune at the current position of the caret, or its transliteration, see func
+// SetTransliterate
+func (l *Device) printRune(r rune) {
+	t := l.transliterate(r)
+	if t == "" {
+		l.printCode(l.encodeRune(r, nil))
+		return
+	}
+	for _, r := range t {
+		l.printCode(l.encodeRune(r, nil))
+	}
+}
+
+// printCode writes the character code at the current position of the caret, inserting it in the
+// insert mode, see func SetInsertMode
+func (l *Device) printCode(c byte) {
+	if l.insert {
+		l.insertCode(c)
+		return
+	}
+	l.writeData(c)
+}
+
+// home moves the cursor to row 0, col 0 and waits for the display to finish
+func (l *Device) home() error {
+	l.writeAll(1 << 1)
+	err := l.waitExec()
+	l.addr = [2]uint8{}
+	l.selectCtl(0)
+	return err
+}
+
+// waitExec waits for the controllers to execute a clear display or return home instruction; by
+// polling the busy flag with the R/W pin, or by sleeping the execution time otherwise. An error is
+// returned if a controller is still busy after 10 times the execution time
+func (l *Device) waitExec() error {
+	d := execWait
+	if l.variant == SPLC780D {
+		d *= 2
+	}
+	if l.pinRW == nil {
+		time.Sleep(d)
+		return nil
+	}
+	deadline := time.Now().Add(d * 10)
+	ctl := l.ctl
+	defer func() { l.ctl = ctl }()
+	for c := 0; c < l.geo.controllers(); c++ {
+		l.ctl = c // Selects the E pin read, see readPins
+		for l.read(cmdInstruction)&0x80 != 0 {
+			if time.Now().After(deadline) {
+				l.busErrors++
+				return errors.New("The display is still busy")
+			}
+		}
+	}
+	return nil
+}
+
+// setCursor moves the cursor to the provided row and col, if within the display or clamped to it,
+// see func SetClampCursor
+func (l *Device) setCursor(row, col uint8) {
+	if l.clampCursor {
+		if row >= l.rows {
+			row = l.rows - 1
+		}
+		if col >= l.cols {
+			col = l.cols - 1
+		}
+	}
+	if row > l.rows-1 || col > l.cols-1 {
+		return
+	}
+	l.setAddr(l.geo.cellAddr(row, col))
+}
+
+// setAddr selects the controller and moves the cursor to the DDRAM address
+func (l *Device) setAddr(ctl int, addr uint8) {
+	l.selectCtl(ctl)
+	l.write(0x80|addr, cmdInstruction)
+	l.addr[ctl] = addr
+}
+
+// selectCtl selects the controller written to. With two controllers, the cursor is moved to it
+func (l *Device) selectCtl(ctl int) {
+	if ctl == l.ctl {
+		return
+	}
+	l.ctl = ctl
+	if l.displayCtl&0b11 != 0 {
+		l.writeDisplay()
+	}
+}
+
+// writeData writes the character at the cursor, keeping track of the content of the display and of
+// the cursor moving on, as the controller does in its entry mode
+func (l *Device) writeData(c byte) {
+	l.write(c, cmdData)
+	l.ddram[l.ctl][l.addr[l.ctl]&0x7f] = c
+	increment := l.entryMode&0b10 != 0
+	l.addr[l.ctl] = nextAddr(l.addr[l.ctl], l.geo.twoLines, increment)
+	if addr, ok := l.geo.jump(l.addr[l.ctl], increment); ok {
+		l.setAddr(l.ctl, addr)
+	}
+}
+
+// cell returns the character written at the row and col
+func (l *Device) cell(row, col uint8) byte {
+	ctl, addr := l.geo.cellAddr(row, col)
+	return l.ddram[ctl][addr&0x7f]
+}
+
+// nextAddr returns the DDRAM address following (or preceding) addr, wrapping between the lines as
+// the controller does
+func nextAddr(addr uint8, twoLines, increment bool) uint8 {
+	if !twoLines {
+		switch {
+		case increment:
+			return (addr + 1) % 0x50
+		case addr == 0:
+			return 0x4f
+		}
+		return addr - 1
+	}
+	switch {
+	case increment && addr == 0x27:
+		return 0x40
+	case increment && addr == 0x67:
+		return 0
+	case increment:
+		return addr + 1
+	case addr == 0:
+		return 0x67
+	case addr == 0x40:
+		return 0x27
+	}
+	return addr - 1
+}
+
+// setDisplayBit sets or clears the bit in the display control mask and writes the mask
+func (l *Device) setDisplayBit(mask uint8, on bool) {
+	if on {
+		l.displayCtl |= mask
+	} else {
+		l.displayCtl &= ^mask
+	}
+	l.writeDisplay()
+}
+
+// writeDisplay writes the display control mask, with the display off while idle if so set (see
+// SetIdleTimeout). With two controllers, only the selected controller shows the cursor
+func (l *Device) writeDisplay() {
+	display := l.displayCtl
+	if l.idle && l.idleDisplay {
+		display &^= 1 << 2
+	}
+	if l.pinE2 == nil {
+		l.write(display, cmdInstruction)
+		return
+	}
+	sel := l.ctl
+	for l.ctl = 0; l.ctl < 2; l.ctl++ {
+		mask := display
+		if l.ctl != sel {
+			mask &^= 0b11
+		}
+		l.write(mask, cmdInstruction)
+	}
+	l.ctl = sel
+}
+
+// writeAll writes the instruction to all controllers of the display
+func (l *Device) writeAll(data uint8) {
+	all := l.all
+	l.all = true
+	l.write(data, cmdInstruction)
+	l.all = all
 }
 
 // setDefaultMasks sets the default values of the different instructions to be used at initialization
 // of the display
 func (l *Device) setDefaultMasks() {
-	l.masks = make(map[string]uint8)
-	l.masks["entryMode"] = 0b100
-	l.masks["display"] = 0b1100
-	l.masks["displayShift"] = 0b10100
-	l.masks["functionSet"] = 0b100000
+	l.entryMode = 0b110
+	l.displayCtl = 0b1100
+	l.functionSet = 0b100000
 	if l.mode == BITMODE8 {
-		l.masks["functionSet"] |= (1 << 4)
+		l.functionSet |= (1 << 4)
 	}
-	if l.rows == 2 {
-		l.masks["functionSet"] |= (1 << 3)
+	if l.geo.twoLines {
+		l.functionSet |= (1 << 3)
 	}
 	if l.sym == DOTS5x11 {
-		l.masks["functionSet"] |= (1 << 2)
+		l.functionSet |= (1 << 2)
 	}
 }
 
@@ -260,28 +772,93 @@ func validateSymm(rows, cols, font uint8) error {
 	return nil
 }
 
-// write writes data to the LCD display, either to be shown or as a command
+// write writes data to the LCD display, either to be shown or as a command, keeping the statistics
+// (see func Stats), recording and tracing it and calling the hooks, if any, unless in bounded memory
+// mode
 func (l *Device) write(data uint8, cmd uint8) {
-	l.pinRS.Write(rpio.State(cmd))
-	if l.mode == BITMODE8 {
-		for i := 0; i < 8; i++ {
-			if data&(1<<i) == 1<<i {
-				l.pinDs[i].High()
-			} else {
-				l.pinDs[i].Low()
-			}
+	l.written++
+	if l.bounded {
+		l.writeByte(data, cmd)
+		if l.tees != nil {
+			l.feedTees(data, cmd, l.writtenCtl())
 		}
+		return
+	}
+	if cmd == cmdInstruction {
+		l.instructions++
+	}
+	if l.cmdRec != nil {
+		l.cmdRec.add(data, cmd, l.writtenCtl())
+	}
+	if l.trace != nil {
+		l.traceWrite(data, cmd, l.writtenCtl())
+	}
+	if l.hooks.Before != nil {
+		l.hooks.Before(data, cmd == cmdData)
+	}
+	start := time.Now()
+	l.writeByte(data, cmd)
+	took := time.Since(start)
+	l.writeTime += took
+	if l.hooks.After != nil {
+		l.hooks.After(data, cmd == cmdData, took)
+	}
+	if l.tees != nil {
+		l.feedTees(data, cmd, l.writtenCtl())
+	}
+}
+
+// writeByte writes data to the LCD display, either to be shown or as a command
+func (l *Device) writeByte(data uint8, cmd uint8) {
+	if l.i2c != nil {
+		if l.i2c.write(data, cmd) != nil {
+			l.busErrors++
+		}
+		return
+	}
+	if l.bus != nil {
+		l.bus.mu.Lock()
+		defer l.bus.mu.Unlock()
+	}
+	if cmd == cmdData {
+		l.pinRS.High()
+	} else {
+		l.pinRS.Low()
+	}
+	if l.mode == BITMODE8 {
+		l.setData(data)
 		l.enableWrite()
 		return
 	}
 	for nibble := 4; nibble >= 0; nibble -= 4 {
-		for i := 0; i < 4; i++ {
-			if data&(1<<(i+nibble)) == 1<<(i+nibble) {
-				l.pinDs[i].High()
-			} else {
-				l.pinDs[i].Low()
-			}
-		}
+		l.setData(data >> nibble)
 		l.enableWrite()
 	}
 }
+
+// writtenCtl returns the controller written to, -1 for all
+func (l *Device) writtenCtl() int {
+	switch {
+	case l.pinE2 == nil:
+		return 0
+	case l.all:
+		return -1
+	}
+	return l.ctl
+}
+
+// setData sets the data pins to the lowest 4 or 8 bits of data, all at once if the GPIO registers
+// are memory mapped (see gpioBank), or one pin at a time otherwise
+func (l *Device) setData(data uint8) {
+	if l.bank != nil && l.rec == nil {
+		l.bank.write(data)
+		return
+	}
+	for i, p := range l.pinDs {
+		if data&(1<<i) != 0 {
+			p.High()
+		} else {
+			p.Low()
+		}
+	}
+}
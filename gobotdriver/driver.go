@@ -0,0 +1,136 @@
+// Package gobotdriver wraps a display as a Gobot driver, so that it can be added to a gobot.Robot
+// along with its other drivers, writing through the pins of a Gobot adaptor (e.g. raspi or firmata)
+// instead of rpio. It is a module of its own, so that Gobot is not a dependency of the driver
+// itself:
+//
+//	go get github.com/hossner/go-st7066u/gobotdriver
+package gobotdriver
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/hossner/go-st7066u"
+	"gobot.io/x/gobot/v2"
+)
+
+// Connection is a Gobot adaptor with digital outputs, such as the raspi and firmata adaptors
+type Connection interface {
+	gobot.Connection
+	DigitalWrite(pin string, val byte) error
+}
+
+// Driver is a display as a gobot.Driver. The display is initialized by Start, and closed by Halt.
+// Use func NewDriver to get a new struct
+type Driver struct {
+	mu                    sync.Mutex
+	name                  string
+	conn                  Connection
+	rows, cols, sym, mode uint8
+	pinRS, pinE, pinL     string
+	pins                  []string
+	lcd                   *st7066u.Device
+}
+
+// connPin is a pin of the adaptor
+type connPin struct {
+	conn Connection
+	pin  string
+}
+
+// NewDriver returns a Driver for the display wired to the pins of the adaptor, given by their names
+// as used by the adaptor (e.g. "7" for physical pin 7 with the raspi adaptor). pinL may be "" if the
+// backlight isn't controlled through a pin. Other arguments are as for st7066u.New
+func NewDriver(conn Connection, nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, pinRS, pinE, pinL string, pins ...string) *Driver {
+	return &Driver{
+		name:  gobot.DefaultName("ST7066U"),
+		conn:  conn,
+		rows:  nrOfRows,
+		cols:  nrOfCols,
+		sym:   charSym,
+		mode:  mode,
+		pinRS: pinRS,
+		pinE:  pinE,
+		pinL:  pinL,
+		pins:  pins,
+	}
+}
+
+// Name implements gobot.Driver
+func (d *Driver) Name() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.name
+}
+
+// SetName implements gobot.Driver
+func (d *Driver) SetName(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.name = name
+}
+
+// Connection implements gobot.Driver
+func (d *Driver) Connection() gobot.Connection {
+	return d.conn
+}
+
+// Start implements gobot.Driver, initializing the display
+func (d *Driver) Start() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.lcd != nil {
+		return errors.New("Driver already started")
+	}
+	ds := make([]st7066u.Pin, len(d.pins))
+	for i, p := range d.pins {
+		ds[i] = d.pin(p)
+	}
+	var l st7066u.Pin
+	if d.pinL != "" {
+		l = d.pin(d.pinL)
+	}
+	lcd, err := st7066u.NewFromPins(d.rows, d.cols, d.sym, d.mode, d.pin(d.pinRS), d.pin(d.pinE), l, ds...)
+	if err != nil {
+		return err
+	}
+	d.lcd = lcd
+	return nil
+}
+
+// Halt implements gobot.Driver, clearing and closing the display
+func (d *Driver) Halt() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.lcd != nil {
+		d.lcd.Close()
+		d.lcd = nil
+	}
+	return nil
+}
+
+// Device returns the display, to be written to as any other Device, or nil if the driver isn't
+// started
+func (d *Driver) Device() *st7066u.Device {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lcd
+}
+
+// pin returns the pin of the adaptor
+func (d *Driver) pin(name string) st7066u.Pin {
+	return connPin{conn: d.conn, pin: name}
+}
+
+// High implements st7066u.Pin. Errors of the adaptor are ignored, as for the GPIO pins
+func (p connPin) High() {
+	p.conn.DigitalWrite(p.pin, 1)
+}
+
+// Low implements st7066u.Pin
+func (p connPin) Low() {
+	p.conn.DigitalWrite(p.pin, 0)
+}
+
+// Output implements st7066u.Pin. The adaptor sets the direction of the pin when written to
+func (p connPin) Output() {}
@@ -0,0 +1,10 @@
+module github.com/hossner/go-st7066u/gobotdriver
+
+go 1.20
+
+require (
+	github.com/hossner/go-st7066u v0.0.0
+	gobot.io/x/gobot/v2 v2.3.0
+)
+
+replace github.com/hossner/go-st7066u => ../
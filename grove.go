@@ -0,0 +1,119 @@
+package st7066u
+
+import (
+	"errors"
+	"os"
+	"time"
+)
+
+// I2C addresses of the Grove-LCD RGB
+const (
+	groveLCDAddr = 0x3e // AIP31068L text controller
+	groveRGBAddr = 0x62 // PCA9633 backlight controller
+)
+
+// Control bytes of the AIP31068L, preceding each instruction or data byte
+const (
+	aipInstruction = 0x80
+	aipData        = 0x40
+)
+
+// Registers of the PCA9633
+const (
+	pcaMode1  = 0x00
+	pcaMode2  = 0x01
+	pcaPWM0   = 0x02 // Blue; green and red follow
+	pcaLEDOut = 0x08
+)
+
+// errNoGPIO is returned by functions needing GPIO pins on a display without them
+var errNoGPIO = errors.New("Not supported for displays on I2C")
+
+// i2cLCD is a text controller written to over I2C instead of GPIO pins, such as the AIP31068L of
+// the Grove-LCD RGB. Its instructions are those of the ST7066U
+type i2cLCD struct {
+	f *os.File
+}
+
+// pca9633 is the RGB backlight of the Grove-LCD RGB
+type pca9633 struct {
+	f *os.File
+}
+
+// NewGroveRGB returns a Device for a Seeed Grove-LCD RGB (16x2, up to v4) on the I2C bus, e.g. 1 for
+// /dev/i2c-1. The text is written to its AIP31068L controller, and the color of the backlight is set
+// through its PCA9633 with SetBacklightColor. There are no GPIO pins, so the functions needing
+// them, e.g. SetBrightness and SetRWPin, are not supported. Failed writes are counted as bus errors,
+// see Stats. An error is returned if another open device uses the addresses of the display on the
+// bus. The v5 of the display, with another backlight controller, is not supported
+func NewGroveRGB(bus int) (*Device, error) {
+	pins := make([]Pin, 8)
+	for i := range pins {
+		pins[i] = noPin{}
+	}
+	g := newDevice(newGeometry(2, 16), DOTS5x8, noPin{}, noPin{}, noPin{}, pins)
+	if err := claimI2C(g, bus, groveLCDAddr, groveRGBAddr); err != nil {
+		return nil, err
+	}
+	lcd, err := openI2C(bus, groveLCDAddr)
+	if err != nil {
+		releasePins(g)
+		return nil, err
+	}
+	rgb, err := openI2C(bus, groveRGBAddr)
+	if err != nil {
+		lcd.Close()
+		releasePins(g)
+		return nil, err
+	}
+	g.i2c = &i2cLCD{f: lcd}
+	g.backend = "i2c"
+	b := &pca9633{f: rgb}
+	for _, reg := range [][2]uint8{
+		{pcaMode1, 0x00},  // Normal mode, oscillator on
+		{pcaMode2, 0x00},  // Not inverted, totem pole outputs
+		{pcaLEDOut, 0xaa}, // All LEDs controlled by their own PWM register
+	} {
+		if _, err := rgb.Write(reg[:]); err != nil {
+			lcd.Close()
+			rgb.Close()
+			releasePins(g)
+			return nil, err
+		}
+	}
+	g.rgb = b
+	g.setDefaultMasks()
+	g.start()
+	return g, nil
+}
+
+// write writes the byte, as an instruction or data depending on cmd
+func (c *i2cLCD) write(data uint8, cmd uint8) error {
+	ctrl := uint8(aipInstruction)
+	if cmd == cmdData {
+		ctrl = aipData
+	}
+	_, err := c.f.Write([]byte{ctrl, data})
+	time.Sleep(pinEWait)
+	return err
+}
+
+// close closes the I2C bus
+func (c *i2cLCD) close() {
+	c.f.Close()
+}
+
+// set implements rgbChannel
+func (b *pca9633) set(duty [3]uint8) error {
+	for i, d := range []uint8{duty[2], duty[1], duty[0]} {
+		if _, err := b.f.Write([]byte{pcaPWM0 + uint8(i), d}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// close implements rgbChannel
+func (b *pca9633) close() {
+	b.f.Close()
+}
@@ -0,0 +1,65 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+// Highlight shows the cells of a region of the row in inverse video, e.g. the selected item of a
+// menu, by loading the inverse of their characters as user-defined characters, which the controller
+// has no other way to show. As there are 8 user-defined characters (4 with 5x11 dot characters, of
+// which only the top 8 rows of dots are inverted), at most as many cells are highlighted, fewer if
+// glyphs are loaded with LoadIcon, and cells with the same character share one. The inverse
+// characters take the place of the least recently printed registered glyphs (see RegisterGlyph),
+// which may change what is shown elsewhere. The cells keep their runes, e.g. for Screenshot. Call
+// it after printing the text of the region, as printing replaces the highlighted cells. Does
+// nothing if the ROM font is left out with the build tag st7066u_small
+func (f *Frame) Highlight(row, col, width uint8) {
+	if row >= f.rows || col >= f.cols {
+		return
+	}
+	f.eachDevice(func(l *Device) {
+		var n uint8
+		for _, s := range l.slots[:l.nrOfSlots()] {
+			if !s.fixed {
+				n++
+			}
+		}
+		if width > n {
+			width = n
+		}
+	})
+	for c := col; c < f.cols && c-col < width; c++ {
+		l := f.device(row, c)
+		if l == nil {
+			continue
+		}
+		code := f.cells[row][c]
+		if i, ok := l.codeSlot(code); ok && l.slots[i].inverse {
+			continue
+		}
+		f.cells[row][c] = l.inverseCode(code, f)
+	}
+}
+
+// inverseCode returns the code of a user-defined character holding the inverse of the character
+// code, loading it if needed, or the code as is if no user-defined character is free. Codes shown in
+// the frame f are not replaced
+func (l *Device) inverseCode(code byte, f *Frame) byte {
+	g := l.codeGlyph(code)
+	for i := range g {
+		g[i] = ^g[i] & 0x1f
+	}
+	l.glyphTick++
+	for i, s := range l.slots[:l.nrOfSlots()] {
+		if s.inverse && s.g == g {
+			l.slots[i].last = l.glyphTick
+			return l.slotCode(i)
+		}
+	}
+	i := l.freeSlot(f)
+	if i < 0 {
+		return code
+	}
+	l.slots[i] = glyphSlot{g: g, r: l.codeRune(code), used: true, inverse: true, last: l.glyphTick}
+	l.writeGlyph(i)
+	return l.slotCode(i)
+}
@@ -0,0 +1,8 @@
+//go:build st7066u_small
+// +build st7066u_small
+
+package st7066u
+
+// Highlight does nothing, as the ROM font is left out with the build tag st7066u_small, see the
+// Highlight of other builds
+func (f *Frame) Highlight(row, col, width uint8) {}
@@ -0,0 +1,119 @@
+// Package httpd exposes a Device over a tiny REST API, so that other machines and scripts can push
+// text to the display without writing Go, e.g. with curl. The endpoints are
+//
+//	POST /line/{n}		The body is printed on row n (from 0), replacing the text of the row
+//	POST /clear		Clears the display
+//	POST /backlight		The body is "on" or "off", or a brightness from 0.0 to 1.0 (see SetBrightness)
+//	POST /diagnostics	Shows the diagnostics page (see ShowDiagnostics), each display full for 3 seconds
+//
+// Successful requests are answered with 204 No Content. There is no authentication, so only serve
+// the API on trusted networks
+package httpd
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hossner/go-st7066u"
+)
+
+const (
+	maxBody         = 1024            // Max nr of bytes read from the body of a request
+	diagnosticsPage = 3 * time.Second // How long each display full of the diagnostics page is shown
+)
+
+var errBacklight = errors.New("Backlight must be on, off or a brightness from 0.0 to 1.0")
+
+// Handler returns a http.Handler serving the API for the device, e.g. to be mounted on a ServeMux
+// of the program with http.StripPrefix
+func Handler(dev *st7066u.Device) http.Handler {
+	return &handler{dev: dev}
+}
+
+// ListenAndServe serves the API for the device on the TCP address, e.g. ":8066", until it fails
+func ListenAndServe(addr string, dev *st7066u.Device) error {
+	return http.ListenAndServe(addr, Handler(dev))
+}
+
+// handler serves the API for a device
+type handler struct {
+	dev *st7066u.Device
+}
+
+// ServeHTTP implements http.Handler
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "clear", path == "backlight", path == "diagnostics", strings.HasPrefix(path, "line/"):
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBody))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	text := strings.TrimRight(string(body), "\r\n")
+	switch path {
+	case "clear":
+		if err := h.dev.Clear(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	case "backlight":
+		if err := h.backlight(text); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case "diagnostics":
+		go h.dev.ShowDiagnostics(diagnosticsPage)
+	default:
+		rows, cols := h.dev.Size()
+		n, err := strconv.Atoi(strings.TrimPrefix(path, "line/"))
+		if err != nil || n < 0 || n >= int(rows) {
+			http.Error(w, "Row must be a number from 0 to "+strconv.Itoa(int(rows)-1), http.StatusBadRequest)
+			return
+		}
+		line := []rune(text)
+		if len(line) < int(cols) {
+			line = append(line, []rune(strings.Repeat(" ", int(cols)-len(line)))...)
+		}
+		h.dev.Update(func(f *st7066u.Frame) { f.PrintAt(uint8(n), 0, string(line)) })
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// backlight turns the backlight on or off, or sets its brightness, as given by the text
+func (h *handler) backlight(text string) error {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "on":
+		h.dev.LedOn(true)
+		return nil
+	case "off":
+		h.dev.LedOn(false)
+		return nil
+	}
+	level, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return errBacklight
+	}
+	if level < 0 || level > 1 {
+		return errBacklight
+	}
+	if err := h.dev.SetBrightness(level); err != nil {
+		return err
+	}
+	h.dev.LedOn(level > 0)
+	return nil
+}
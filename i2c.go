@@ -0,0 +1,26 @@
+//go:build linux && !tinygo
+// +build linux,!tinygo
+
+package st7066u
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// i2cSlave is the ioctl request setting the address of the I2C device written to
+const i2cSlave = 0x0703
+
+// openI2C opens the I2C bus (e.g. 1 for /dev/i2c-1) for writing to the device at the address
+func openI2C(bus int, addr uint8) (*os.File, error) {
+	f, err := os.OpenFile(fmt.Sprintf("/dev/i2c-%d", bus), os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), i2cSlave, uintptr(addr)); errno != 0 {
+		f.Close()
+		return nil, errno
+	}
+	return f, nil
+}
@@ -0,0 +1,234 @@
+// Package icons is a set of common 5x8 glyphs for the LCD display; battery levels, WiFi bars,
+// arrows, a bell, a heart, a thermometer, a drop and a speaker. Load them into a user-defined
+// character with LoadIcon, e.g. lcd.LoadIcon(icons.BatteryHalf, 0), or register them for a rune with
+// RegisterGlyph. They can also be looked up by name, see func ByName
+package icons
+
+import (
+	"sort"
+
+	"github.com/hossner/go-st7066u"
+)
+
+// Battery levels
+var (
+	BatteryEmpty = st7066u.MustParseGlyph(
+		".XXX.",
+		"X...X",
+		"X...X",
+		"X...X",
+		"X...X",
+		"X...X",
+		"XXXXX",
+		".....",
+	)
+	BatteryLow = st7066u.MustParseGlyph(
+		".XXX.",
+		"X...X",
+		"X...X",
+		"X...X",
+		"X...X",
+		"XXXXX",
+		"XXXXX",
+		".....",
+	)
+	BatteryHalf = st7066u.MustParseGlyph(
+		".XXX.",
+		"X...X",
+		"X...X",
+		"XXXXX",
+		"XXXXX",
+		"XXXXX",
+		"XXXXX",
+		".....",
+	)
+	BatteryFull = st7066u.MustParseGlyph(
+		".XXX.",
+		"XXXXX",
+		"XXXXX",
+		"XXXXX",
+		"XXXXX",
+		"XXXXX",
+		"XXXXX",
+		".....",
+	)
+)
+
+// WiFi signal strengths, as bars
+var (
+	WifiNone = st7066u.MustParseGlyph(
+		".....",
+		".....",
+		".....",
+		".....",
+		".....",
+		".....",
+		"X.X.X",
+		".....",
+	)
+	WifiLow = st7066u.MustParseGlyph(
+		".....",
+		".....",
+		".....",
+		".....",
+		".....",
+		"X....",
+		"X....",
+		".....",
+	)
+	WifiMedium = st7066u.MustParseGlyph(
+		".....",
+		".....",
+		".....",
+		"..X..",
+		"..X..",
+		"X.X..",
+		"X.X..",
+		".....",
+	)
+	WifiFull = st7066u.MustParseGlyph(
+		".....",
+		"....X",
+		"....X",
+		"..X.X",
+		"..X.X",
+		"X.X.X",
+		"X.X.X",
+		".....",
+	)
+)
+
+// Arrows
+var (
+	ArrowUp = st7066u.MustParseGlyph(
+		"..X..",
+		".XXX.",
+		"X.X.X",
+		"..X..",
+		"..X..",
+		"..X..",
+		"..X..",
+		".....",
+	)
+	ArrowDown = st7066u.MustParseGlyph(
+		"..X..",
+		"..X..",
+		"..X..",
+		"..X..",
+		"X.X.X",
+		".XXX.",
+		"..X..",
+		".....",
+	)
+	ArrowLeft = st7066u.MustParseGlyph(
+		".....",
+		"..X..",
+		".X...",
+		"XXXXX",
+		".X...",
+		"..X..",
+		".....",
+		".....",
+	)
+	ArrowRight = st7066u.MustParseGlyph(
+		".....",
+		"..X..",
+		"...X.",
+		"XXXXX",
+		"...X.",
+		"..X..",
+		".....",
+		".....",
+	)
+)
+
+// Other icons
+var (
+	Bell = st7066u.MustParseGlyph(
+		"..X..",
+		".XXX.",
+		".XXX.",
+		".XXX.",
+		"XXXXX",
+		".....",
+		"..X..",
+		".....",
+	)
+	Heart = st7066u.MustParseGlyph(
+		".....",
+		".X.X.",
+		"XXXXX",
+		"XXXXX",
+		".XXX.",
+		"..X..",
+		".....",
+		".....",
+	)
+	Thermometer = st7066u.MustParseGlyph(
+		"..X..",
+		".X.X.",
+		".X.X.",
+		".X.X.",
+		".XXX.",
+		"XXXXX",
+		"XXXXX",
+		".XXX.",
+	)
+	Drop = st7066u.MustParseGlyph(
+		"..X..",
+		"..X..",
+		".X.X.",
+		".X.X.",
+		"X...X",
+		"X...X",
+		".XXX.",
+		".....",
+	)
+	Speaker = st7066u.MustParseGlyph(
+		"...X.",
+		"..XX.",
+		"XXXX.",
+		"XXXX.",
+		"XXXX.",
+		"..XX.",
+		"...X.",
+		".....",
+	)
+)
+
+// byName holds the icons by their names, as used by func ByName
+var byName = map[string]st7066u.Glyph{
+	"BatteryEmpty": BatteryEmpty,
+	"BatteryLow":   BatteryLow,
+	"BatteryHalf":  BatteryHalf,
+	"BatteryFull":  BatteryFull,
+	"WifiNone":     WifiNone,
+	"WifiLow":      WifiLow,
+	"WifiMedium":   WifiMedium,
+	"WifiFull":     WifiFull,
+	"ArrowUp":      ArrowUp,
+	"ArrowDown":    ArrowDown,
+	"ArrowLeft":    ArrowLeft,
+	"ArrowRight":   ArrowRight,
+	"Bell":         Bell,
+	"Heart":        Heart,
+	"Thermometer":  Thermometer,
+	"Drop":         Drop,
+	"Speaker":      Speaker,
+}
+
+// ByName returns the icon with the name, which is the name of its variable, e.g. "BatteryHalf"
+func ByName(name string) (st7066u.Glyph, bool) {
+	g, ok := byName[name]
+	return g, ok
+}
+
+// Names returns the names of all icons, sorted
+func Names() []string {
+	names := make([]string, 0, len(byName))
+	for n := range byName {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,92 @@
+package st7066u
+
+import "time"
+
+// SetIdleTimeout turns the backlight off after the duration d without activity, to save power and
+// backlight lifetime, e.g. on battery powered projects. Printing (Print, PrintAt, PrintByte and
+// PrintRune) and Wake are activity, and turn the backlight on again; updates by Update and the
+// widgets are not, so that e.g. a clock doesn't keep the backlight on. The on/off state set by
+// LedOn is kept while idle. A duration of 0 turns the timeout off, waking the display if idle. See
+// also SetIdleDisplayOff
+func (l *Device) SetIdleTimeout(d time.Duration) {
+	l.do(func() {
+		l.stopIdleTimer()
+		l.idleTimeout = d
+		if d > 0 {
+			l.idleTimer = time.AfterFunc(d, func() { l.do(l.goIdle) })
+		}
+		l.touch()
+		l.wake()
+	})
+}
+
+// SetIdleDisplayOff sets if the display is turned off as well as the backlight when idle, see
+// SetIdleTimeout, e.g. for OLED displays where the display itself wears. Off by default
+func (l *Device) SetIdleDisplayOff(on bool) {
+	l.do(func() {
+		l.idleDisplay = on
+		if l.idle {
+			l.writeDisplay()
+		}
+	})
+}
+
+// Wake turns the backlight (and display) on again if idle, and restarts the idle timeout, e.g. when
+// a button is pressed
+func (l *Device) Wake() {
+	l.do(l.touch)
+}
+
+// Idle returns true if the backlight (and display) is off due to inactivity, see SetIdleTimeout
+func (l *Device) Idle() bool {
+	var idle bool
+	l.doWait(func() { idle = l.idle })
+	return idle
+}
+
+// touch registers activity, restarting the idle timeout and waking the display if idle
+func (l *Device) touch() {
+	if l.idleTimeout <= 0 {
+		return
+	}
+	l.lastActive = time.Now()
+	l.idleTimer.Reset(l.idleTimeout)
+	l.wake()
+}
+
+// wake turns the backlight, and display, on again if idle
+func (l *Device) wake() {
+	if !l.idle {
+		return
+	}
+	l.idle = false
+	l.applyBacklight()
+	l.writeDisplay()
+}
+
+// goIdle turns the backlight, and the display if so set, off if the idle timeout has passed since
+// the last activity
+func (l *Device) goIdle() {
+	if l.idleTimeout <= 0 || l.idle || time.Since(l.lastActive) < l.idleTimeout {
+		return
+	}
+	l.idle = true
+	l.applyBacklight()
+	if l.idleDisplay {
+		l.writeDisplay()
+	}
+}
+
+// stopIdleTimer stops the idle timeout, if any
+func (l *Device) stopIdleTimer() {
+	if l.idleTimer != nil {
+		l.idleTimer.Stop()
+		l.idleTimer = nil
+	}
+	l.idleTimeout = 0
+}
+
+// backlightOn returns true if the backlight is to be on; turned on by LedOn, and not idle
+func (l *Device) backlightOn() bool {
+	return l.ledOn && !l.idle
+}
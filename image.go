@@ -0,0 +1,58 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+import (
+	"image"
+	"image/color"
+)
+
+// Layout of an image rendered by RenderImage, in pixels
+const (
+	imageDot    = 4  // Size of a dot
+	imagePitch  = 5  // Distance between the dots of a character
+	imageGap    = 4  // Extra distance between characters
+	imageBorder = 10 // Border around the characters
+)
+
+// RenderImage returns an image of what the display shows, dot by dot (see func Dots), e.g. to be
+// encoded as PNG by tests comparing the output of layout code, or by web pages. A dot is drawn as
+// 4x4 pixels, on a background in the color of an RGB backlight, or in yellow green, darker while
+// the backlight is off
+func (l *Device) RenderImage() image.Image {
+	m := l.Dots()
+	base := color.RGBA{R: 168, G: 198, B: 78, A: 0xff}
+	if c := l.BacklightColor(); c != White {
+		base = color.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff}
+	}
+	light := 1.0
+	if !m.Backlight {
+		light = 0.45
+	}
+	palette := color.Palette{shade(base, light), shade(base, light*0.9), shade(base, 0.18)}
+	w := imageBorder*2 + int(m.Cols)*(m.Width*imagePitch+imageGap) - imageGap
+	h := imageBorder*2 + int(m.Rows)*(m.Height*imagePitch+imageGap) - imageGap
+	img := image.NewPaletted(image.Rect(0, 0, w, h), palette)
+	for y, row := range m.Dots {
+		top := imageBorder + y*imagePitch + y/m.Height*imageGap
+		for x, on := range row {
+			left := imageBorder + x*imagePitch + x/m.Width*imageGap
+			idx := uint8(1)
+			if on {
+				idx = 2
+			}
+			for dy := 0; dy < imageDot; dy++ {
+				for dx := 0; dx < imageDot; dx++ {
+					img.SetColorIndex(left+dx, top+dy, idx)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// shade returns the color scaled by the factor
+func shade(c color.RGBA, f float64) color.RGBA {
+	return color.RGBA{R: uint8(float64(c.R) * f), G: uint8(float64(c.G) * f), B: uint8(float64(c.B) * f), A: 0xff}
+}
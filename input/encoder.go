@@ -0,0 +1,57 @@
+package input
+
+import "time"
+
+// DefaultStepsPerDetent is the nr of quadrature steps between two detents of most rotary encoders
+const DefaultStepsPerDetent = 4
+
+// quadSteps is the step of each transition of the A and B pins, indexed by the previous and the
+// current state as prev<<2 | cur, with the state as A<<1 | B. Invalid transitions, e.g. from a
+// missed sample, count as no step
+var quadSteps = [16]int{0, -1, 1, 0, 1, 0, 0, -1, -1, 0, 0, 1, 0, 1, -1, 0}
+
+// Quadrature is a decoder for a rotary encoder. Feed it with samples of the A and B pins, and it
+// returns a Clockwise or CounterClockwise event per detent turned. StepsPerDetent can be changed
+// before the first sample, e.g. to 2 for encoders with half the detents. Use func NewQuadrature to
+// get a new struct
+type Quadrature struct {
+	StepsPerDetent int
+
+	encoder int
+	state   int
+	steps   int // Steps since the last detent, negative counter-clockwise
+	started bool
+}
+
+// NewQuadrature returns a new decoder for the rotary encoder with the id
+func NewQuadrature(encoder int) *Quadrature {
+	return &Quadrature{StepsPerDetent: DefaultStepsPerDetent, encoder: encoder}
+}
+
+// Sample feeds the decoder with the state of the A and B pins at the time now, and returns the
+// event of a detent turned, if any. Samples must be fed often enough to see every change of the pins
+// while the encoder is turned, e.g. every ms
+func (q *Quadrature) Sample(a, b bool, now time.Time) []Event {
+	state := 0
+	if a {
+		state |= 0b10
+	}
+	if b {
+		state |= 0b01
+	}
+	if !q.started {
+		q.state, q.started = state, true
+		return nil
+	}
+	q.steps += quadSteps[q.state<<2|state]
+	q.state = state
+	switch {
+	case q.steps >= q.StepsPerDetent:
+		q.steps = 0
+		return []Event{{Button: q.encoder, Type: Clockwise, Time: now}}
+	case q.steps <= -q.StepsPerDetent:
+		q.steps = 0
+		return []Event{{Button: q.encoder, Type: CounterClockwise, Time: now}}
+	}
+	return nil
+}
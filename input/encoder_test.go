@@ -0,0 +1,50 @@
+package input
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQuadrature(t *testing.T) {
+	tests := []struct {
+		name   string
+		steps  int    // Steps per detent, the default if 0
+		states string // States of the A and B pins sampled
+		want   []EventType
+	}{
+		{"clockwise", 0, "00 10 11 01 00", []EventType{Clockwise}},
+		{"counter-clockwise", 0, "00 01 11 10 00", []EventType{CounterClockwise}},
+		{"two detents", 0, "00 10 11 01 00 10 11 01 00", []EventType{Clockwise, Clockwise}},
+		{"back and forth", 0, "00 10 11 01 00 01 11 10 00", []EventType{Clockwise, CounterClockwise}},
+		{"not a detent", 0, "00 10 11 01", nil},
+		{"turned back", 0, "00 10 11 10 00", nil},
+		{"repeated samples", 0, "00 00 10 10 11 11 01 01 00 00", []EventType{Clockwise}},
+		{"missed sample", 0, "00 11 01 00 10 11 01 00", []EventType{Clockwise}},
+		{"half detents", 2, "00 10 11 01 00", []EventType{Clockwise, Clockwise}},
+		{"started mid detent", 0, "11 01 00 10 11", []EventType{Clockwise}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := NewQuadrature(1)
+			if tt.steps > 0 {
+				q.StepsPerDetent = tt.steps
+			}
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			var got []EventType
+			for _, s := range strings.Fields(tt.states) {
+				for _, e := range q.Sample(s[0] == '1', s[1] == '1', now) {
+					if e.Button != 1 {
+						t.Errorf("%v from encoder %d, want 1", e.Type, e.Button)
+					}
+					got = append(got, e.Type)
+				}
+				now = now.Add(time.Millisecond)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
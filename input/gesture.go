@@ -0,0 +1,146 @@
+// Package input turns button presses into events for UIs on the LCD display, e.g. menus and value
+// editors. A Gestures engine is fed with samples of the raw state of a button, and emits typed
+// events for short, long and double presses, and repeats while a button is held. A Watcher samples
+// buttons, e.g. on GPIO pins, in a polling loop, Navigate drives a menu and Adjust a value
+// editor with the events
+package input
+
+import "time"
+
+// EventType is the type of an input event
+type EventType int
+
+// The types of input events
+const (
+	Press            EventType = iota // Short press, emitted when the button is released
+	LongPress                         // The button has been held for the long press duration
+	DoublePress                       // Two short presses within the double press window
+	Repeat                            // Emitted repeatedly while the button is held after a long press
+	Clockwise                         // A rotary encoder was turned one detent clockwise
+	CounterClockwise                  // A rotary encoder was turned one detent counter-clockwise
+)
+
+// Event is an input event from a button
+type Event struct {
+	Button int // Id of the button, as given to NewGestures (or of the encoder, see NewQuadrature)
+	Type   EventType
+	Time   time.Time
+}
+
+// Default durations used by NewGestures
+const (
+	DefaultDebounce       = time.Millisecond * 20
+	DefaultLongPress      = time.Millisecond * 600
+	DefaultDoublePress    = time.Millisecond * 300
+	DefaultRepeatInterval = time.Millisecond * 150
+)
+
+// Gestures is a gesture engine for one button. Feed it with samples of the raw state of the button,
+// e.g. from a polling loop, and it returns the events recognized. The durations can be changed
+// before the first sample; setting DoublePress to 0 emits short presses without waiting for a
+// possible second press, and setting RepeatInterval to 0 disables repeats. Use func NewGestures to
+// get a new struct
+type Gestures struct {
+	Debounce       time.Duration // How long the raw state must be stable to be accepted
+	LongPress      time.Duration // How long the button must be held for a long press
+	DoublePress    time.Duration // Max time from the release of a short press to the next press
+	RepeatInterval time.Duration // Time between repeats while held after a long press
+
+	button     int
+	raw        bool      // Last raw state
+	rawAt      time.Time // When the raw state last changed
+	pressed    bool      // Debounced state
+	downAt     time.Time
+	long       bool // A long press has been emitted for the current press
+	nextRepeat time.Time
+	waiting    bool // A short press is waiting for a possible second press
+	upAt       time.Time
+}
+
+// NewGestures returns a new gesture engine for the button with the id, using the default durations
+func NewGestures(button int) *Gestures {
+	return &Gestures{
+		Debounce:       DefaultDebounce,
+		LongPress:      DefaultLongPress,
+		DoublePress:    DefaultDoublePress,
+		RepeatInterval: DefaultRepeatInterval,
+		button:         button,
+	}
+}
+
+// Sample feeds the engine with the raw state of the button at the time now, and returns the events
+// recognized, if any. Samples should be fed regularly (e.g. every 5 ms), also when the state doesn't
+// change, as long presses and repeats are recognized while the button is held
+func (g *Gestures) Sample(pressed bool, now time.Time) []Event {
+	if pressed != g.raw {
+		g.raw, g.rawAt = pressed, now
+	}
+	var events []Event
+	if g.raw != g.pressed && now.Sub(g.rawAt) >= g.Debounce {
+		g.pressed = g.raw
+		if g.pressed {
+			g.downAt, g.long = now, false
+		} else {
+			events = g.up(now, events)
+		}
+	}
+	if g.pressed {
+		if !g.long && now.Sub(g.downAt) >= g.LongPress {
+			if g.waiting {
+				// The second press turned into a long press, so the first was a single one
+				g.waiting = false
+				events = append(events, g.event(Press, now))
+			}
+			g.long = true
+			g.nextRepeat = now.Add(g.RepeatInterval)
+			events = append(events, g.event(LongPress, now))
+		} else if g.long && g.RepeatInterval > 0 && !now.Before(g.nextRepeat) {
+			g.nextRepeat = g.nextRepeat.Add(g.RepeatInterval)
+			events = append(events, g.event(Repeat, now))
+		}
+	} else if g.waiting && now.Sub(g.upAt) > g.DoublePress {
+		g.waiting = false
+		events = append(events, g.event(Press, now))
+	}
+	return events
+}
+
+// up handles the debounced release of the button
+func (g *Gestures) up(now time.Time, events []Event) []Event {
+	if g.long {
+		return events
+	}
+	switch {
+	case g.waiting:
+		g.waiting = false
+		return append(events, g.event(DoublePress, now))
+	case g.DoublePress > 0:
+		g.waiting, g.upAt = true, now
+		return events
+	}
+	return append(events, g.event(Press, now))
+}
+
+// event returns an event of the type from the button
+func (g *Gestures) event(t EventType, now time.Time) Event {
+	return Event{Button: g.button, Type: t, Time: now}
+}
+
+// String returns the name of the event type
+func (t EventType) String() string {
+	switch t {
+	case Press:
+		return "Press"
+	case LongPress:
+		return "LongPress"
+	case DoublePress:
+		return "DoublePress"
+	case Repeat:
+		return "Repeat"
+	case Clockwise:
+		return "Clockwise"
+	case CounterClockwise:
+		return "CounterClockwise"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,60 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// step is the state of a button for a duration, in ms
+type step struct {
+	pressed bool
+	ms      int
+}
+
+func TestGestures(t *testing.T) {
+	tests := []struct {
+		name   string
+		double time.Duration
+		steps  []step
+		want   []EventType
+	}{
+		{"press", DefaultDoublePress, []step{{true, 100}, {false, 500}}, []EventType{Press}},
+		{"press, waiting", DefaultDoublePress, []step{{true, 100}, {false, 200}}, nil},
+		{"double press", DefaultDoublePress, []step{{true, 100}, {false, 100}, {true, 100}, {false, 500}},
+			[]EventType{DoublePress}},
+		{"two presses", DefaultDoublePress, []step{{true, 100}, {false, 400}, {true, 100}, {false, 500}},
+			[]EventType{Press, Press}},
+		{"long press", DefaultDoublePress, []step{{true, 700}, {false, 500}}, []EventType{LongPress}},
+		{"repeats", DefaultDoublePress, []step{{true, 1000}, {false, 500}},
+			[]EventType{LongPress, Repeat, Repeat}},
+		{"press, long press", DefaultDoublePress, []step{{true, 100}, {false, 100}, {true, 700}, {false, 500}},
+			[]EventType{Press, LongPress}},
+		{"bounces", DefaultDoublePress, []step{{true, 10}, {false, 10}, {true, 10}, {false, 500}}, nil},
+		{"no double press", 0, []step{{true, 100}, {false, 50}}, []EventType{Press}},
+		{"no double press, twice", 0, []step{{true, 100}, {false, 100}, {true, 100}, {false, 50}},
+			[]EventType{Press, Press}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGestures(3)
+			g.DoublePress = tt.double
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			var got []EventType
+			for _, s := range tt.steps {
+				for ms := 0; ms < s.ms; ms += 5 {
+					for _, e := range g.Sample(s.pressed, now) {
+						if e.Button != 3 {
+							t.Errorf("%v from button %d, want 3", e.Type, e.Button)
+						}
+						got = append(got, e.Type)
+					}
+					now = now.Add(time.Millisecond * 5)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
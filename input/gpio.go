@@ -0,0 +1,48 @@
+//go:build linux && !tinygo
+// +build linux,!tinygo
+
+package input
+
+import (
+	"time"
+
+	"github.com/stianeikeland/go-rpio"
+)
+
+// WatchButtons starts a Watcher for buttons wired from the GPIO pins to gnd, using the internal
+// pull-up resistors, so that a button is pressed while its pin is low. The ids of the buttons are
+// the indexes of their pins, e.g. 0 for the first pin. Double presses are not recognized, so that
+// presses are emitted without delay. rpio must be open, e.g. by a display returned by st7066u.New,
+// until the watcher is stopped
+func WatchButtons(pins ...rpio.Pin) *Watcher {
+	gestures := make([]*Gestures, len(pins))
+	for i, p := range pins {
+		p.Input()
+		p.PullUp()
+		gestures[i] = NewGestures(i)
+		gestures[i].DoublePress = 0
+	}
+	return Watch(func(button int) bool {
+		return pins[button].Read() == rpio.Low
+	}, gestures...)
+}
+
+// WatchEncoder starts a Watcher for a rotary encoder with a push-button, with the A, B and switch
+// pins wired to GPIO pins and the common pin(s) to gnd, using the internal pull-ups. Turning the
+// encoder emits Clockwise and CounterClockwise events with id 0 (swap pinA and pinB if reversed),
+// and the push-button emits the events of a button with id 1, as for WatchButtons. The pins are
+// sampled every EncoderPollInterval. rpio must be open until the watcher is stopped
+func WatchEncoder(pinA, pinB, pinSW rpio.Pin) *Watcher {
+	for _, p := range []rpio.Pin{pinA, pinB, pinSW} {
+		p.Input()
+		p.PullUp()
+	}
+	q := NewQuadrature(0)
+	g := NewGestures(1)
+	g.DoublePress = 0
+	return watch(EncoderPollInterval, func(now time.Time) []Event {
+		// The pins are high at the detents, with the pull-ups
+		events := q.Sample(pinA.Read() == rpio.Low, pinB.Read() == rpio.Low, now)
+		return append(events, g.Sample(pinSW.Read() == rpio.Low, now)...)
+	})
+}
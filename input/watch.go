@@ -0,0 +1,199 @@
+package input
+
+import (
+	"sync"
+	"time"
+)
+
+// PollInterval is how often the buttons are sampled by a Watcher
+const PollInterval = time.Millisecond * 5
+
+// Watcher samples buttons in a polling loop, feeding their gesture engines, and emits the events
+// recognized on its Events channel. Use func Watch or WatchButtons to get a new struct
+type Watcher struct {
+	Events <-chan Event // Events of the buttons, closed when the watcher is stopped
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// EncoderPollInterval is how often the A and B pins of a rotary encoder are sampled by a Watcher,
+// which is more often than buttons, so that no steps are missed when the encoder is turned fast
+const EncoderPollInterval = time.Millisecond
+
+// Watch starts a Watcher sampling the buttons, every PollInterval. read returns if the button with
+// the id is pressed, and is called with the id of each of the gesture engines (see NewGestures).
+// Events not received are dropped when the Events channel is full
+func Watch(read func(button int) bool, gestures ...*Gestures) *Watcher {
+	return watch(PollInterval, func(now time.Time) []Event {
+		var events []Event
+		for _, g := range gestures {
+			events = append(events, g.Sample(read(g.button), now)...)
+		}
+		return events
+	})
+}
+
+// watch starts a Watcher calling sample every interval, and emitting the events returned
+func watch(interval time.Duration, sample func(now time.Time) []Event) *Watcher {
+	events := make(chan Event, 16)
+	w := &Watcher{
+		Events: events,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(w.done)
+		defer close(events)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case now := <-t.C:
+				for _, e := range sample(now) {
+					select {
+					case events <- e:
+					default:
+					}
+				}
+			}
+		}
+	}()
+	return w
+}
+
+// Stop stops the watcher, and closes its Events channel
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+	<-w.done
+}
+
+// Navigator is a UI navigated by buttons, e.g. a st7066u.Menu
+type Navigator interface {
+	Up()
+	Down()
+	Select()
+	Back() bool
+}
+
+// Navigate navigates the UI with the events, until the channel is closed, e.g. the Events of a
+// Watcher. up, down, sel and back are the ids of the buttons; Press and Repeat of up and down move
+// the selection, Press of sel selects and Press of back goes back. Without a back button, i.e. if
+// back is -1, a LongPress of sel goes back instead. Turning a rotary encoder clockwise moves the
+// selection down, and counter-clockwise up
+func Navigate(events <-chan Event, nav Navigator, up, down, sel, back int) {
+	for e := range events {
+		switch {
+		case e.Type == Clockwise:
+			nav.Down()
+		case e.Type == CounterClockwise:
+			nav.Up()
+		case e.Button == up && (e.Type == Press || e.Type == Repeat):
+			nav.Up()
+		case e.Button == down && (e.Type == Press || e.Type == Repeat):
+			nav.Down()
+		case e.Button == sel && e.Type == Press:
+			nav.Select()
+		case e.Button == back && e.Type == Press,
+			back < 0 && e.Button == sel && e.Type == LongPress:
+			nav.Back()
+		}
+	}
+}
+
+// Adjuster is a value adjusted by buttons, e.g. a st7066u.Editor
+type Adjuster interface {
+	Increase()
+	Decrease()
+	Select()
+	Back() bool
+}
+
+// Adjust adjusts the value with the events, until it is selected or the adjusting is cancelled, or
+// the channel is closed. It may be called from the action of a menu item, with the events driving
+// the menu by Navigate. inc, dec, sel and back are the ids of the buttons; Press and Repeat of inc
+// and dec step the value, Press of sel selects it and Press of back cancels. Without a back button,
+// i.e. if back is -1, a LongPress of sel cancels instead. Turning a rotary encoder clockwise
+// increases the value, and counter-clockwise decreases it
+func Adjust(events <-chan Event, adj Adjuster, inc, dec, sel, back int) {
+	for e := range events {
+		switch {
+		case e.Type == Clockwise:
+			adj.Increase()
+		case e.Type == CounterClockwise:
+			adj.Decrease()
+		case e.Button == inc && (e.Type == Press || e.Type == Repeat):
+			adj.Increase()
+		case e.Button == dec && (e.Type == Press || e.Type == Repeat):
+			adj.Decrease()
+		case e.Button == sel && e.Type == Press:
+			adj.Select()
+			return
+		case e.Button == back && e.Type == Press,
+			back < 0 && e.Button == sel && e.Type == LongPress:
+			adj.Back()
+			return
+		}
+	}
+}
+
+// TextEditor is text edited by buttons, e.g. a st7066u.TextInput
+type TextEditor interface {
+	Adjuster
+	Right()
+	Delete()
+}
+
+// EditText edits the text with the events, until it is confirmed or the editing is cancelled, or the
+// channel is closed. inc, dec, sel and back are the ids of the buttons; Press and Repeat of inc and
+// dec step the character at the cursor through the charset, Press of sel moves the cursor to the
+// next character and a LongPress of sel confirms the text, Press of back deletes the character at
+// the cursor and a LongPress of back cancels. Without a back button, i.e. if back is -1, characters
+// can't be deleted and the editing can't be cancelled. Turning a rotary encoder clockwise steps the
+// character forward, and counter-clockwise back
+func EditText(events <-chan Event, ed TextEditor, inc, dec, sel, back int) {
+	for e := range events {
+		switch {
+		case e.Type == Clockwise:
+			ed.Increase()
+		case e.Type == CounterClockwise:
+			ed.Decrease()
+		case e.Button == inc && (e.Type == Press || e.Type == Repeat):
+			ed.Increase()
+		case e.Button == dec && (e.Type == Press || e.Type == Repeat):
+			ed.Decrease()
+		case e.Button == sel && e.Type == Press:
+			ed.Right()
+		case e.Button == sel && e.Type == LongPress:
+			ed.Select()
+			return
+		case e.Button == back && e.Type == Press:
+			ed.Delete()
+		case e.Button == back && e.Type == LongPress:
+			ed.Back()
+			return
+		}
+	}
+}
+
+// Trigger calls fn for each event of the type from the button, e.g. a DoublePress of a button to
+// show the diagnostics page with ShowDiagnostics of the display, and passes all other events on to
+// the returned channel, e.g. to drive a menu with Navigate. fn is called in its own goroutine, so it
+// may take a while. The returned channel is closed when events is closed
+func Trigger(events <-chan Event, button int, t EventType, fn func()) <-chan Event {
+	out := make(chan Event, cap(events))
+	go func() {
+		defer close(out)
+		for e := range events {
+			if e.Button == button && e.Type == t {
+				go fn()
+				continue
+			}
+			out <- e
+		}
+	}()
+	return out
+}
@@ -0,0 +1,39 @@
+package st7066u
+
+// SetInsertMode turns the insert mode on or off. In insert mode, characters printed by Print,
+// PrintAt, PrintByte, PrintBytes and PrintRune are inserted at the cursor, moving the rest of the row
+// right by one and dropping the character shifted past the last column, e.g. for simple text editors
+// and input fields. Otherwise they overwrite what is at the cursor, the default. Only the characters
+// that changed are written. Update and PrintRTL always overwrite
+func (l *Device) SetInsertMode(on bool) {
+	l.do(func() {
+		l.insert = on
+	})
+}
+
+// insertCode inserts the character code at the cursor, moving the rest of the row right by one, and
+// moves the cursor on by one. Outside the visible part of the display, the code is written as is
+func (l *Device) insertCode(c byte) {
+	row, col, ok := l.cursor()
+	if !ok {
+		l.writeData(c)
+		return
+	}
+	// The last cell changed by the insertion
+	last := l.cols - 1
+	for ; last > col; last-- {
+		if l.cell(row, last-1) != l.cell(row, last) {
+			break
+		}
+	}
+	prev := l.cell(row, col)
+	l.writeData(c)
+	for i := col + 1; i <= last; i++ {
+		cur := l.cell(row, i)
+		l.writeData(prev)
+		prev = cur
+	}
+	if last > col {
+		l.setCursor(row, col+1)
+	}
+}
@@ -0,0 +1,45 @@
+package st7066u
+
+import "strings"
+
+// katakana maps full-width katakana and Japanese punctuation to the half-width forms the ROM has.
+// Voiced and semi-voiced kana are two half-width characters, the kana and the (han)dakuten
+var katakana = map[rune]string{
+	'ァ': "ｧ", 'ア': "ｱ", 'ィ': "ｨ", 'イ': "ｲ", 'ゥ': "ｩ", 'ウ': "ｳ",
+	'ェ': "ｪ", 'エ': "ｴ", 'ォ': "ｫ", 'オ': "ｵ", 'カ': "ｶ", 'ガ': "ｶﾞ",
+	'キ': "ｷ", 'ギ': "ｷﾞ", 'ク': "ｸ", 'グ': "ｸﾞ", 'ケ': "ｹ", 'ゲ': "ｹﾞ",
+	'コ': "ｺ", 'ゴ': "ｺﾞ", 'サ': "ｻ", 'ザ': "ｻﾞ", 'シ': "ｼ", 'ジ': "ｼﾞ",
+	'ス': "ｽ", 'ズ': "ｽﾞ", 'セ': "ｾ", 'ゼ': "ｾﾞ", 'ソ': "ｿ", 'ゾ': "ｿﾞ",
+	'タ': "ﾀ", 'ダ': "ﾀﾞ", 'チ': "ﾁ", 'ヂ': "ﾁﾞ", 'ッ': "ｯ", 'ツ': "ﾂ",
+	'ヅ': "ﾂﾞ", 'テ': "ﾃ", 'デ': "ﾃﾞ", 'ト': "ﾄ", 'ド': "ﾄﾞ", 'ナ': "ﾅ",
+	'ニ': "ﾆ", 'ヌ': "ﾇ", 'ネ': "ﾈ", 'ノ': "ﾉ", 'ハ': "ﾊ", 'バ': "ﾊﾞ",
+	'パ': "ﾊﾟ", 'ヒ': "ﾋ", 'ビ': "ﾋﾞ", 'ピ': "ﾋﾟ", 'フ': "ﾌ", 'ブ': "ﾌﾞ",
+	'プ': "ﾌﾟ", 'ヘ': "ﾍ", 'ベ': "ﾍﾞ", 'ペ': "ﾍﾟ", 'ホ': "ﾎ", 'ボ': "ﾎﾞ",
+	'ポ': "ﾎﾟ", 'マ': "ﾏ", 'ミ': "ﾐ", 'ム': "ﾑ", 'メ': "ﾒ", 'モ': "ﾓ",
+	'ャ': "ｬ", 'ヤ': "ﾔ", 'ュ': "ｭ", 'ユ': "ﾕ", 'ョ': "ｮ", 'ヨ': "ﾖ",
+	'ラ': "ﾗ", 'リ': "ﾘ", 'ル': "ﾙ", 'レ': "ﾚ", 'ロ': "ﾛ", 'ヮ': "ﾜ",
+	'ワ': "ﾜ", 'ヰ': "ｲ", 'ヱ': "ｴ", 'ヲ': "ｦ", 'ン': "ﾝ", 'ヴ': "ｳﾞ",
+	'ヵ': "ｶ", 'ヶ': "ｹ", 'ヷ': "ﾜﾞ", 'ヸ': "ｲﾞ", 'ヹ': "ｴﾞ", 'ヺ': "ｦﾞ",
+	'・': "･", 'ー': "ｰ",
+	'。': "｡", '「': "｢", '」': "｣", '、': "､", '゛': "ﾞ", '゜': "ﾟ",
+	'\u3000': " ", // Ideographic space
+}
+
+// Katakana returns the text with full-width katakana, hiragana and Japanese punctuation converted to
+// the half-width forms of the character ROM, so that Japanese text prints natively, e.g. "ステータス"
+// as "ｽﾃｰﾀｽ". Hiragana is converted to katakana, as the ROM has no hiragana. Voiced and semi-voiced
+// kana take two characters, e.g. "ガ" becomes "ｶﾞ", so the text may get longer. Other runes are kept
+func Katakana(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			r += 'ァ' - 'ぁ' // Hiragana to katakana
+		}
+		if s, ok := katakana[r]; ok {
+			b.WriteString(s)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
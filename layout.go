@@ -0,0 +1,192 @@
+package st7066u
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// marqueeStep is how long a marquee field of a Layout shows each position of its text
+const marqueeStep = time.Millisecond * 400
+
+// marqueeGap is shown between the end and the start of the text of a marquee field
+const marqueeGap = "   "
+
+// Overflow is how text wider than its field is shown, see func DecideOverflow
+type Overflow uint8
+
+// OverflowAuto, OverflowNone, OverflowTruncate and OverflowMarquee; the policy or decision of how
+// text wider than its field is shown
+const (
+	OverflowAuto     Overflow = iota // Policy only; decided from the content and language
+	OverflowNone                     // The text fits the field
+	OverflowTruncate                 // The text is cut at the width of the field
+	OverflowMarquee                  // The text is scrolled through the field
+)
+
+// Field is a named field of a Layout, width cells at the row and col
+type Field struct {
+	Name            string
+	Row, Col, Width uint8
+	Format          string   // fmt format of the value, e.g. "%.1f°C", "%v" if empty
+	Right           bool     // The text is right-aligned, e.g. for numbers, instead of left-aligned
+	Overflow        Overflow // How a value wider than the field is shown, see func DecideOverflow
+}
+
+// Layout is a set of named fields at fixed positions of the display, e.g. a dashboard, whose values
+// are set by name instead of printed at coordinates. Use func NewLayout to get a new struct
+type Layout struct {
+	l       *Device
+	mu      sync.Mutex
+	fields  []Field
+	index   map[string]int // Index of the field of each name
+	texts   []string       // Text of each field, as last set
+	lang    string         // Language of the values, see func SetLanguage
+	marquee []bool         // The text of each field is scrolled
+	steps   []int          // Position of the text shown by each marquee field
+	stop    chan struct{}  // Closed to stop scrolling the marquee fields, nil if not scrolling
+	done    chan struct{}
+}
+
+// NewLayout returns a Layout of the fields. Nothing is drawn until the values of the fields are set.
+// An error is returned if the names of the fields aren't unique
+func (l *Device) NewLayout(fields ...Field) (*Layout, error) {
+	ly := &Layout{
+		l:      l,
+		fields: append([]Field(nil), fields...),
+		index:  make(map[string]int, len(fields)),
+		texts:  make([]string, len(fields)),
+
+		marquee: make([]bool, len(fields)),
+		steps:   make([]int, len(fields)),
+	}
+	for i, fd := range fields {
+		if _, ok := ly.index[fd.Name]; ok {
+			return nil, fmt.Errorf("Field %q is given more than once", fd.Name)
+		}
+		ly.index[fd.Name] = i
+	}
+	return ly, nil
+}
+
+// Set sets the value of the field of the name, formatted by the format of the field, and writes the
+// field, padded with spaces. A value wider than the field is truncated or scrolled through the field
+// as a marquee, as decided by DecideOverflow with the overflow policy of the field and the language
+// of the layout (see SetLanguage); marquee fields are scrolled by a goroutine of the layout until
+// their values fit or Stop is called. Only the cells that changed are written. An error is returned
+// if the layout has no field of the name
+func (ly *Layout) Set(name string, value interface{}) error {
+	ly.mu.Lock()
+	i, ok := ly.index[name]
+	if !ok {
+		ly.mu.Unlock()
+		return fmt.Errorf("Unknown field %q", name)
+	}
+	fd := ly.fields[i]
+	format := fd.Format
+	if format == "" {
+		format = "%v"
+	}
+	text := fmt.Sprintf(format, value)
+	if text != ly.texts[i] {
+		ly.texts[i], ly.steps[i] = text, 0
+		ly.marquee[i] = DecideOverflow(text, ly.lang, fd.Width, fd.Overflow) == OverflowMarquee
+	}
+	if ly.marquee[i] && ly.stop == nil {
+		ly.stop, ly.done = make(chan struct{}), make(chan struct{})
+		go ly.scroll(ly.stop, ly.done)
+	}
+	text = ly.shown(i)
+	ly.mu.Unlock()
+	ly.l.Update(func(f *Frame) { f.printField(fd.Row, fd.Col, fd.Width, text, fd.Right) })
+	return nil
+}
+
+// SetLanguage sets the language of the values of the fields, as an IETF language tag, e.g. "de" or
+// "pt-BR", used to decide if values wider than their fields are truncated or scrolled, see func
+// DecideOverflow. "" by default. Values set before are not decided again
+func (ly *Layout) SetLanguage(lang string) {
+	ly.mu.Lock()
+	ly.lang = lang
+	ly.mu.Unlock()
+}
+
+// Stop stops scrolling the marquee fields, if any, leaving them as shown. Setting a value wider than
+// its field starts scrolling again
+func (ly *Layout) Stop() {
+	ly.mu.Lock()
+	stop, done := ly.stop, ly.done
+	ly.stop, ly.done = nil, nil
+	ly.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// shown returns the text shown by the field of the index; the text set, or the window of it shown
+// by a marquee field
+func (ly *Layout) shown(i int) string {
+	if !ly.marquee[i] {
+		return ly.texts[i]
+	}
+	r := []rune(ly.texts[i] + marqueeGap)
+	out := make([]rune, ly.fields[i].Width)
+	for j := range out {
+		out[j] = r[(ly.steps[i]+j)%len(r)]
+	}
+	return string(out)
+}
+
+// scroll scrolls the marquee fields by one position every marqueeStep, until stopped or no field is a
+// marquee
+func (ly *Layout) scroll(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(marqueeStep)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		ly.mu.Lock()
+		var fields []Field
+		var texts []string
+		for i, fd := range ly.fields {
+			if ly.marquee[i] {
+				ly.steps[i]++
+				fields, texts = append(fields, fd), append(texts, ly.shown(i))
+			}
+		}
+		if fields == nil {
+			if ly.stop == stop {
+				ly.stop, ly.done = nil, nil
+			}
+			ly.mu.Unlock()
+			return
+		}
+		ly.mu.Unlock()
+		ly.l.Update(func(f *Frame) {
+			for i, fd := range fields {
+				f.printField(fd.Row, fd.Col, fd.Width, texts[i], fd.Right)
+			}
+		})
+	}
+}
+
+// Redraw draws all fields again with their values, e.g. after the display has been used for
+// something else
+func (ly *Layout) Redraw() {
+	ly.l.Update(ly.Render)
+}
+
+// Render implements Screen, drawing all fields with their values in the frame, so that a layout can
+// be one of the screens of a ScreenManager. Fields not set are drawn blank
+func (ly *Layout) Render(f *Frame) {
+	ly.mu.Lock()
+	defer ly.mu.Unlock()
+	for i, fd := range ly.fields {
+		f.printField(fd.Row, fd.Col, fd.Width, ly.shown(i), fd.Right)
+	}
+}
@@ -0,0 +1,41 @@
+package st7066u
+
+import "testing"
+
+func TestLayoutOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		overflow Overflow
+		value    string
+		shown    string // Shown at once
+		scrolled string // Shown after scrolling one position, "" if not scrolled
+	}{
+		{"fits", "", OverflowMarquee, "Volume", "Volume    ", ""},
+		{"truncated", "", OverflowTruncate, "Temperature", "Temperatur", ""},
+		{"marquee fitting", "", OverflowMarquee, "Volume up!", "Volume up!", ""},
+		{"marquee wider", "", OverflowMarquee, "Temperature", "Temperatur", "emperature"},
+		{"auto, filler cut", "en", OverflowAuto, "Lights on...", "Lights on.", ""},
+		{"auto, word kept", "en", OverflowAuto, "Temperatures", "Temperatur", ""},
+		{"auto, word lost", "en", OverflowAuto, "Set the alarm", "Set the al", "et the ala"},
+		{"auto, compound", "de", OverflowAuto, "Temperaturen", "Temperatur", "emperature"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d, s := newTestDevice(t, "1602", BITMODE4)
+			ly, err := d.NewLayout(Field{Name: "f", Row: 1, Col: 3, Width: 10, Overflow: tt.overflow})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer ly.Stop()
+			ly.SetLanguage(tt.lang)
+			ly.Set("f", tt.value)
+			checkLines(t, s, "", "   "+tt.shown)
+			// With the build tag st7066u_small, OverflowAuto always truncates
+			if tt.scrolled != "" && DecideOverflow(tt.value, tt.lang, 10, tt.overflow) == OverflowMarquee {
+				waitLines(t, s, "                ", "   "+tt.scrolled+"   ")
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package st7066u
+
+import (
+	"strconv"
+	"sync"
+)
+
+// The runes registered for the scroll bar of a List, its track and its thumb
+const (
+	scrollTrack = '┊'
+	scrollThumb = '┃'
+)
+
+// List is a scrollable list of items in a region of the display, one item per row, with the selected
+// item marked by a cursor marker and a scroll bar in the last column when there are more items than
+// rows, drawn by user-defined characters (registered as '┊' and '┃', see RegisterGlyph). The items
+// are given by a function called only for the items shown, so lists may have thousands of items.
+// Navigate with Up, Down and Select, e.g. with func Navigate of package input. Only the cells that
+// changed are written. Use func NewList to get a new struct
+type List struct {
+	l                       *Device
+	mu                      sync.Mutex
+	row, col, width, height uint8
+	count                   int
+	item                    func(index int) string
+	action                  func(index int)
+	cursor, top             int // The selected item, and the first item shown
+	marker                  rune
+}
+
+// NewList returns a List of count items in the region at the row and col, width cells wide and
+// height rows high, and draws it with the first item selected. item returns the text of the item of
+// the index, and is called as items are shown
+func (l *Device) NewList(row, col, width, height uint8, count int, item func(index int) string) *List {
+	ls := &List{
+		l:      l,
+		row:    row,
+		col:    col,
+		width:  width,
+		height: height,
+		count:  count,
+		item:   item,
+		marker: '→',
+	}
+	ls.draw()
+	return ls
+}
+
+// SetAction sets the function called with the index of the selected item by Select
+func (ls *List) SetAction(action func(index int)) {
+	ls.mu.Lock()
+	ls.action = action
+	ls.mu.Unlock()
+}
+
+// SetMarker sets the rune marking the selected item, '→' by default
+func (ls *List) SetMarker(marker rune) {
+	ls.mu.Lock()
+	ls.marker = marker
+	ls.mu.Unlock()
+	ls.draw()
+}
+
+// SetCount sets the nr of items, e.g. when items are added, keeping the selected item within them,
+// and draws the list again
+func (ls *List) SetCount(count int) {
+	ls.mu.Lock()
+	ls.count = count
+	if ls.cursor >= count {
+		ls.cursor = count - 1
+	}
+	if ls.cursor < 0 {
+		ls.cursor = 0
+	}
+	ls.mu.Unlock()
+	ls.draw()
+}
+
+// Up selects the previous item, if any
+func (ls *List) Up() {
+	ls.move(-1)
+}
+
+// Down selects the next item, if any
+func (ls *List) Down() {
+	ls.move(1)
+}
+
+// Select calls the action of the list with the index of the selected item, if any, see SetAction
+func (ls *List) Select() {
+	ls.mu.Lock()
+	action, cursor, count := ls.action, ls.cursor, ls.count
+	ls.mu.Unlock()
+	if action != nil && count > 0 {
+		action(cursor)
+	}
+}
+
+// Back does nothing, as a list has no parent, and returns false. It makes a List an input.Navigator
+func (ls *List) Back() bool {
+	return false
+}
+
+// Selected returns the index of the selected item, or -1 if the list has no items
+func (ls *List) Selected() int {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if ls.count == 0 {
+		return -1
+	}
+	return ls.cursor
+}
+
+// State returns the index of the selected item, see type Scene
+func (ls *List) State() string {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return strconv.Itoa(ls.cursor)
+}
+
+// SetState selects the item of a state returned by State, or the last item if the list has fewer
+// items now, and draws the list
+func (ls *List) SetState(state string) error {
+	cursor, err := strconv.Atoi(state)
+	if err != nil {
+		return err
+	}
+	ls.mu.Lock()
+	if cursor >= ls.count {
+		cursor = ls.count - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	ls.cursor = cursor
+	ls.mu.Unlock()
+	ls.draw()
+	return nil
+}
+
+// Redraw draws the list again, e.g. after the display has been used for something else or the texts
+// of the items have changed
+func (ls *List) Redraw() {
+	ls.draw()
+}
+
+// move moves the cursor by delta items, within the items, and scrolls to keep it shown
+func (ls *List) move(delta int) {
+	ls.mu.Lock()
+	c := ls.cursor + delta
+	if c < 0 || c >= ls.count {
+		ls.mu.Unlock()
+		return
+	}
+	ls.cursor = c
+	ls.mu.Unlock()
+	ls.draw()
+}
+
+// draw draws the items shown, scrolling the list to keep the selected item shown
+func (ls *List) draw() {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	rows := int(ls.height)
+	if ls.cursor < ls.top {
+		ls.top = ls.cursor
+	}
+	if ls.cursor >= ls.top+rows {
+		ls.top = ls.cursor - rows + 1
+	}
+	if max := ls.count - rows; ls.top > max && max >= 0 {
+		ls.top = max
+	}
+	texts := make([]string, rows)
+	for r := range texts {
+		if i := ls.top + r; i < ls.count {
+			texts[r] = ls.item(i)
+		}
+	}
+	bar := ls.count > rows && rows > 0
+	thumb := 0
+	if bar {
+		thumb = (ls.top*(rows-1) + (ls.count-rows)/2) / (ls.count - rows)
+	}
+	row, col, width, marker := ls.row, ls.col, ls.width, ls.marker
+	cursor := -1
+	if ls.count > 0 {
+		cursor = ls.cursor - ls.top
+	}
+	ls.l.Update(func(f *Frame) {
+		if row >= f.rows || col >= f.cols || width == 0 {
+			return
+		}
+		if int(col)+int(width) > int(f.cols) {
+			width = f.cols - col
+		}
+		if bar && width < 3 {
+			bar = false
+		}
+		textWidth := width - 1
+		if bar {
+			f.eachDevice((*Device).registerScrollGlyphs)
+			textWidth--
+		}
+		r0, c0 := f.row, f.col
+		for r, text := range texts {
+			if int(row)+r >= int(f.rows) {
+				break
+			}
+			f.SetCursor(row+uint8(r), col)
+			if r == cursor {
+				f.PrintRune(marker)
+			} else {
+				f.PrintByte(0x20)
+			}
+			f.printField(row+uint8(r), col+1, textWidth, text, false)
+			if bar {
+				f.SetCursor(row+uint8(r), col+width-1)
+				if r == thumb {
+					f.PrintRune(scrollThumb)
+				} else {
+					f.PrintRune(scrollTrack)
+				}
+			}
+		}
+		f.row, f.col = r0, c0
+	})
+}
+
+// registerScrollGlyphs registers the glyphs of the scroll bar of a List, if not registered already
+func (l *Device) registerScrollGlyphs() {
+	if l.glyphs == nil {
+		l.glyphs = make(map[rune]Glyph)
+	}
+	if _, ok := l.glyphs[scrollTrack]; !ok {
+		l.glyphs[scrollTrack] = Glyph{0x04, 0x00, 0x04, 0x00, 0x04, 0x00, 0x04, 0x00}
+	}
+	if _, ok := l.glyphs[scrollThumb]; !ok {
+		l.glyphs[scrollThumb] = Glyph{0x0e, 0x0e, 0x0e, 0x0e, 0x0e, 0x0e, 0x0e, 0x0e}
+	}
+}
@@ -0,0 +1,151 @@
+//go:build tinygo
+// +build tinygo
+
+package st7066u
+
+import (
+	"errors"
+	"machine"
+	"os"
+)
+
+// pinState is the level read from an input pin
+type pinState uint8
+
+// Levels of an input pin
+const (
+	pinLow pinState = iota
+	pinHigh
+)
+
+// NoPin is used instead of a pin for the L pin, when the backlight isn't controlled through a GPIO
+// pin, e.g. when it is tied straight to 5V
+const NoPin = machine.NoPin
+
+// machinePin is a GPIO pin of the board, with TinyGo
+type machinePin machine.Pin
+
+// gpioBank isn't available with TinyGo, where the data pins are set one at a time
+type gpioBank struct{}
+
+// New returns a Device struct used as a handler for the LCD display, on a board supported by
+// TinyGo (e.g. a Raspberry Pi Pico or an ESP32). Arguments are as for New with rpio, but with the
+// pins of the board, e.g. machine.GP2
+func New(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, pinRS, pinE, pinL machine.Pin, pins ...machine.Pin) (*Device, error) {
+	if err := validateSymm(nrOfRows, nrOfCols, charSym); err != nil {
+		return nil, err
+	}
+	return newMachine(newGeometry(nrOfRows, nrOfCols), charSym, mode, pinRS, pinE, pinL, pins)
+}
+
+// NewDualController returns a Device for a 40x4 display with two controllers, as NewDualController
+// with rpio, on a board supported by TinyGo
+func NewDualController(mode uint8, pinRS, pinE1, pinE2, pinL machine.Pin, pins ...machine.Pin) (*Device, error) {
+	return newMachine(dualGeometry(), DOTS5x8, mode, pinRS, pinE1, pinL, pins, pinE2)
+}
+
+// NewFromProfile returns a Device for a display of a known geometry, as NewFromProfile with rpio, on
+// a board supported by TinyGo
+func NewFromProfile(profile string, charSym uint8, mode uint8, pinRS, pinE, pinL machine.Pin, pins ...machine.Pin) (*Device, error) {
+	geo, err := profileGeometry(profile, charSym)
+	if err != nil {
+		return nil, err
+	}
+	if geo.controllers() > 1 {
+		return nil, errors.New("Profile " + profile + " has two controllers, use NewDualController")
+	}
+	return newMachine(geo, charSym, mode, pinRS, pinE, pinL, pins)
+}
+
+// newMachine returns a Device with the geometry using the pins of the board. The second E pin is
+// provided for dual controller displays only
+func newMachine(geo geometry, charSym uint8, mode uint8, pinRS, pinE, pinL machine.Pin, pins []machine.Pin, pinE2 ...machine.Pin) (*Device, error) {
+	if err := validatePinMode(mode, len(pins)); err != nil {
+		return nil, err
+	}
+	ds := make([]Pin, len(pins))
+	for i, p := range pins {
+		ds[i] = machinePin(p)
+	}
+	claim := append([]Pin{machinePin(pinRS), machinePin(pinE)}, ds...)
+	var l Pin = noPin{}
+	if pinL != NoPin {
+		l = machinePin(pinL)
+		claim = append(claim, l)
+	}
+	g := newDevice(geo, charSym, machinePin(pinRS), machinePin(pinE), l, ds)
+	g.backend = "machine"
+	if len(pinE2) > 0 {
+		g.pinE2 = machinePin(pinE2[0])
+		claim = append(claim, g.pinE2)
+	}
+	if err := claimPins(g, claim...); err != nil {
+		return nil, err
+	}
+	g.setDefaultMasks()
+	g.start()
+	return g, nil
+}
+
+// closeGPIO releases what the device uses of the GPIO, as the last step of Close. Nothing with TinyGo
+func (l *Device) closeGPIO() {}
+
+// SetRWPin sets the pin used for the R/W pin of the display, as SetRWPin with rpio
+func (l *Device) SetRWPin(pinRW machine.Pin) error {
+	return l.setRWPin(machinePin(pinRW))
+}
+
+// SetRGBPins sets the pins driving the red, green and blue LEDs of the backlight, as SetRGBPins with
+// rpio
+func (l *Device) SetRGBPins(pinR, pinG, pinB machine.Pin, activeLow bool) error {
+	return l.setRGBPins([3]Pin{machinePin(pinR), machinePin(pinG), machinePin(pinB)}, activeLow)
+}
+
+// SetBrightness isn't supported with TinyGo, where the PWM peripherals differ between boards
+func (l *Device) SetBrightness(level float64) error {
+	return errors.New("Brightness is not supported with TinyGo")
+}
+
+// hasPwmBacklight reports if the brightness of the backlight can be set
+func (l *Device) hasPwmBacklight() bool {
+	return false
+}
+
+// setPwmDuty sets the duty of the L pin. Never used with TinyGo
+func (l *Device) setPwmDuty(duty uint32) {}
+
+// openI2C isn't supported with TinyGo, where there is no /dev/i2c-N
+func openI2C(bus int, addr uint8) (*os.File, error) {
+	return nil, errors.New("I2C devices are not supported with TinyGo")
+}
+
+// write implements nothing, see type gpioBank
+func (b *gpioBank) write(data uint8) {}
+
+// High implements Pin
+func (p machinePin) High() {
+	machine.Pin(p).High()
+}
+
+// Low implements Pin
+func (p machinePin) Low() {
+	machine.Pin(p).Low()
+}
+
+// Output implements Pin
+func (p machinePin) Output() {
+	machine.Pin(p).Configure(machine.PinConfig{Mode: machine.PinOutput})
+}
+
+// Input implements inPin
+func (p machinePin) Input() {
+	machine.Pin(p).Configure(machine.PinConfig{Mode: machine.PinInput})
+}
+
+// Read implements inPin
+func (p machinePin) Read() pinState {
+	if machine.Pin(p).Get() {
+		return pinHigh
+	}
+	return pinLow
+}
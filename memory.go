@@ -0,0 +1,27 @@
+package st7066u
+
+// SetBoundedMemory turns the bounded memory mode on or off. In bounded memory mode the memory used
+// by the device is fixed and small, as needed on e.g. a Pi Zero: the scrollback of a Terminal is
+// limited to the rows of the display, recordings (see funcs RecordPins and RecordCommands) and
+// traces (see func SetTrace) record nothing, the write hooks aren't called, and of the Stats only
+// BytesWritten and BusErrors are counted. Recordings and traces running when it is turned on are
+// stopped. Optional subsystems can also be left out at compile time with the build tag
+// st7066u_small
+func (l *Device) SetBoundedMemory(on bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bounded = on
+	if !on {
+		return
+	}
+	l.cmdRec = nil
+	l.unwrapPins()
+	l.trace = nil
+}
+
+// boundedMemory reports if the bounded memory mode is on
+func (l *Device) boundedMemory() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.bounded
+}
@@ -0,0 +1,222 @@
+package st7066u
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MenuItem is an item of a Menu; an action, or a submenu if it has items of its own
+type MenuItem struct {
+	Label  string
+	Action func()      // Called when the item is selected, if it has no items
+	Items  []*MenuItem // Items of the submenu, if any
+}
+
+// Menu is a menu filling the display, with one item per row, the selected item marked by a cursor
+// marker, and submenus. Lists longer than the nr of rows scroll, keeping the selected item shown.
+// Navigate with Up, Down, Select and Back, e.g. wired to buttons or a rotary encoder (see package
+// input). Only the cells that changed are written. Use func NewMenu to get a new struct
+type Menu struct {
+	l         *Device
+	mu        sync.Mutex
+	levels    []menuLevel // The root menu and the submenus entered, the current last
+	marker    rune
+	highlight bool
+}
+
+// menuLevel is a menu or submenu entered, with its selected item and the first item shown
+type menuLevel struct {
+	items       []*MenuItem
+	cursor, top int
+}
+
+// NewMenu returns a Menu of the items, and draws it with the first item selected
+func (l *Device) NewMenu(items ...*MenuItem) *Menu {
+	m := &Menu{
+		l:      l,
+		levels: []menuLevel{{items: items}},
+		marker: '→',
+	}
+	m.draw()
+	return m
+}
+
+// SetMarker sets the rune marking the selected item, '→' by default
+func (m *Menu) SetMarker(marker rune) {
+	m.mu.Lock()
+	m.marker = marker
+	m.mu.Unlock()
+	m.draw()
+}
+
+// SetHighlight turns highlighting the label of the selected item in inverse video on or off, so
+// that it stands out more than by the marker alone. See Frame.Highlight for the limits; at most the
+// first 8 cells of the label are highlighted, and none on displays narrower than 3 columns. Off by
+// default
+func (m *Menu) SetHighlight(on bool) {
+	m.mu.Lock()
+	m.highlight = on
+	m.mu.Unlock()
+	m.draw()
+}
+
+// Up selects the previous item, if any
+func (m *Menu) Up() {
+	m.move(-1)
+}
+
+// Down selects the next item, if any
+func (m *Menu) Down() {
+	m.move(1)
+}
+
+// Select enters the submenu of the selected item, or calls its action. The action is called after
+// the menu is drawn, and may call the methods of the Menu and the Device
+func (m *Menu) Select() {
+	m.mu.Lock()
+	lv := m.levels[len(m.levels)-1]
+	if len(lv.items) == 0 {
+		m.mu.Unlock()
+		return
+	}
+	item := lv.items[lv.cursor]
+	if len(item.Items) > 0 {
+		m.levels = append(m.levels, menuLevel{items: item.Items})
+	}
+	m.mu.Unlock()
+	m.draw()
+	if len(item.Items) == 0 && item.Action != nil {
+		item.Action()
+	}
+}
+
+// Back leaves the submenu shown, returning to its parent with the item of the submenu selected. false
+// is returned if the root menu is shown
+func (m *Menu) Back() bool {
+	m.mu.Lock()
+	if len(m.levels) == 1 {
+		m.mu.Unlock()
+		return false
+	}
+	m.levels = m.levels[:len(m.levels)-1]
+	m.mu.Unlock()
+	m.draw()
+	return true
+}
+
+// Selected returns the selected item, or nil if the menu shown has no items
+func (m *Menu) Selected() *MenuItem {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lv := m.levels[len(m.levels)-1]
+	if len(lv.items) == 0 {
+		return nil
+	}
+	return lv.items[lv.cursor]
+}
+
+// State returns the selected item of the root menu and of each submenu entered, e.g. "2/0", see
+// type Scene
+func (m *Menu) State() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cursors := make([]string, len(m.levels))
+	for i, lv := range m.levels {
+		cursors[i] = strconv.Itoa(lv.cursor)
+	}
+	return strings.Join(cursors, "/")
+}
+
+// SetState enters the submenus of a state returned by State, selecting its items, and draws the menu.
+// An error is returned, leaving the menu as it is, if the items of the state aren't in the menu
+func (m *Menu) SetState(state string) error {
+	cursors := strings.Split(state, "/")
+	m.mu.Lock()
+	levels := []menuLevel{{items: m.levels[0].items}}
+	for i, c := range cursors {
+		lv := &levels[i]
+		cursor, err := strconv.Atoi(c)
+		if err == nil && (cursor < 0 || cursor >= len(lv.items) && cursor > 0) {
+			err = fmt.Errorf("No menu item %d", cursor)
+		}
+		last := i == len(cursors)-1
+		if err == nil && !last && (len(lv.items) == 0 || len(lv.items[cursor].Items) == 0) {
+			err = fmt.Errorf("Menu item %d has no items", cursor)
+		}
+		if err != nil {
+			m.mu.Unlock()
+			return err
+		}
+		lv.cursor = cursor
+		if !last {
+			levels = append(levels, menuLevel{items: lv.items[cursor].Items})
+		}
+	}
+	m.levels = levels
+	m.mu.Unlock()
+	m.draw()
+	return nil
+}
+
+// Redraw draws the menu again, e.g. after the display has been used for something else or the labels
+// of the items have changed
+func (m *Menu) Redraw() {
+	m.draw()
+}
+
+// move moves the cursor by delta items, within the items, and scrolls to keep it shown
+func (m *Menu) move(delta int) {
+	m.mu.Lock()
+	lv := &m.levels[len(m.levels)-1]
+	c := lv.cursor + delta
+	if c < 0 || c >= len(lv.items) {
+		m.mu.Unlock()
+		return
+	}
+	lv.cursor = c
+	m.mu.Unlock()
+	m.draw()
+}
+
+// draw draws the menu shown, scrolling it to keep the selected item shown
+func (m *Menu) draw() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	lv := &m.levels[len(m.levels)-1]
+	rows := int(m.l.rows)
+	if lv.cursor < lv.top {
+		lv.top = lv.cursor
+	}
+	if lv.cursor >= lv.top+rows {
+		lv.top = lv.cursor - rows + 1
+	}
+	labels := make([]string, 0, rows)
+	subs := make([]bool, 0, rows)
+	for i := lv.top; i < len(lv.items) && i < lv.top+rows; i++ {
+		labels = append(labels, lv.items[i].Label)
+		subs = append(subs, len(lv.items[i].Items) > 0)
+	}
+	cursor, marker, highlight := lv.cursor-lv.top, m.marker, m.highlight
+	m.l.Update(func(f *Frame) {
+		f.Clear()
+		for r, label := range labels {
+			f.SetCursor(uint8(r), 0)
+			if r == cursor {
+				f.PrintRune(marker)
+			} else {
+				f.PrintByte(0x20)
+			}
+			f.Print(label)
+			if highlight && r == cursor && f.cols > 2 {
+				f.Highlight(uint8(r), 1, f.cols-2) // Between the marker and the '>' of a submenu
+			}
+			if subs[r] {
+				f.SetCursor(uint8(r), f.cols-1)
+				f.PrintRune('>')
+			}
+		}
+		f.SetCursor(uint8(cursor), 0)
+	})
+}
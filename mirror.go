@@ -0,0 +1,52 @@
+package st7066u
+
+import "strings"
+
+// SetMirror sets a function that is called with the text of every row whose content has changed,
+// e.g. to forward what is shown on the display to a speech synthesizer or a text log, so that an
+// appliance using the display as its primary UI can be used by visually impaired users. Trailing
+// spaces are trimmed from the text, and the function is called with all rows when set. nil
+// removes the function
+func (l *Device) SetMirror(fn func(row uint8, text string)) {
+	l.do(func() {
+		l.mirror = fn
+		l.mirrored = make([]string, l.rows)
+		for r := range l.mirrored {
+			l.mirrored[r] = "\x00" // Never equal to any content, so all rows are reported
+		}
+	})
+}
+
+// mirrorChanges returns a function calling the mirror function for each row that has changed since
+// the last call. It is called while holding the device, while the returned function is not
+func (l *Device) mirrorChanges() func() {
+	fn := l.mirror
+	if fn == nil {
+		return func() {}
+	}
+	var rows []uint8
+	var texts []string
+	for r := range l.mirrored {
+		text := l.rowText(uint8(r))
+		if text != l.mirrored[r] {
+			l.mirrored[r] = text
+			rows = append(rows, uint8(r))
+			texts = append(texts, text)
+		}
+	}
+	return func() {
+		for i, r := range rows {
+			fn(r, texts[i])
+		}
+	}
+}
+
+// rowText returns the text shown on the row, with trailing spaces trimmed
+func (l *Device) rowText(row uint8) string {
+	var b strings.Builder
+	for c := uint8(0); c < l.cols; c++ {
+		b.WriteRune(l.codeRune(l.cell(row, c)))
+	}
+
+	return strings.TrimRight(b.String(), " ")
+}
@@ -0,0 +1,264 @@
+package mqtt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MQTT control packet types
+const (
+	packetConnect   = 1
+	packetConnAck   = 2
+	packetPublish   = 3
+	packetSubscribe = 8
+	packetPingReq   = 12
+)
+
+// DefaultKeepAlive is the keep alive interval used by Dial if none is given
+const DefaultKeepAlive = time.Second * 60
+
+// Options are the options of a connection to a broker, see func Dial
+type Options struct {
+	ClientID           string        // Id of the client, unique per broker
+	Username, Password string        // Credentials, if required by the broker
+	KeepAlive          time.Duration // Max time between packets sent to the broker, DefaultKeepAlive if 0
+}
+
+// Client is a minimal MQTT 3.1.1 client, subscribing to topics with QoS 0 over a plain TCP
+// connection. It doesn't reconnect; when the connection is lost, Done is closed and Err returns why.
+// Use func Dial to get a new struct
+type Client struct {
+	conn     net.Conn
+	wmu      sync.Mutex // Serializes writes of packets
+	mu       sync.Mutex
+	subs     []subscription
+	packetID uint16
+	err      error
+	done     chan struct{}
+}
+
+// subscription is a topic subscribed to, and its handler
+type subscription struct {
+	topic   string
+	handler func(topic string, payload []byte)
+}
+
+// Dial connects to the broker at the TCP address, e.g. "broker:1883"
+func Dial(addr string, opts Options) (*Client, error) {
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = DefaultKeepAlive
+	}
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{conn: conn, done: make(chan struct{})}
+	if err := c.connect(opts); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go c.read()
+	go c.ping(opts.KeepAlive)
+	return c, nil
+}
+
+// Subscribe implements Subscriber, subscribing to the topic with QoS 0
+func (c *Client) Subscribe(topic string, handler func(topic string, payload []byte)) error {
+	c.mu.Lock()
+	c.packetID++
+	if c.packetID == 0 {
+		c.packetID = 1
+	}
+	id := c.packetID
+	c.subs = append(c.subs, subscription{topic: topic, handler: handler})
+	c.mu.Unlock()
+	var body []byte
+	body = appendUint16(body, id)
+	body = appendString(body, topic)
+	body = append(body, 0) // QoS 0
+	return c.write(packetSubscribe<<4|0b0010, body)
+}
+
+// Close closes the connection to the broker
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Done returns a channel closed when the connection is lost or closed
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the connection was lost, once Done is closed
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// connect sends the CONNECT packet and waits for the CONNACK of the broker
+func (c *Client) connect(opts Options) error {
+	flags := byte(0b10) // Clean session
+	body := appendString(nil, "MQTT")
+	body = append(body, 4) // Protocol level of 3.1.1
+	if opts.Username != "" {
+		flags |= 0b10000000
+	}
+	if opts.Password != "" {
+		flags |= 0b01000000
+	}
+	body = append(body, flags)
+	body = appendUint16(body, uint16(opts.KeepAlive/time.Second))
+	body = appendString(body, opts.ClientID)
+	if opts.Username != "" {
+		body = appendString(body, opts.Username)
+	}
+	if opts.Password != "" {
+		body = appendString(body, opts.Password)
+	}
+	if err := c.write(packetConnect<<4, body); err != nil {
+		return err
+	}
+	c.conn.SetReadDeadline(time.Now().Add(time.Second * 10))
+	defer c.conn.SetReadDeadline(time.Time{})
+	typ, ack, err := readPacket(bufio.NewReader(io.LimitReader(c.conn, 4)))
+	if err != nil {
+		return err
+	}
+	if typ>>4 != packetConnAck || len(ack) != 2 {
+		return errors.New("The broker didn't acknowledge the connection")
+	}
+	if ack[1] != 0 {
+		return fmt.Errorf("The broker refused the connection, return code %d", ack[1])
+	}
+	return nil
+}
+
+// read reads packets from the broker, passing the messages published to the handlers of the topics
+// subscribed to, until the connection is lost
+func (c *Client) read() {
+	r := bufio.NewReader(c.conn)
+	for {
+		typ, body, err := readPacket(r)
+		if err != nil {
+			c.mu.Lock()
+			c.err = err
+			c.mu.Unlock()
+			close(c.done)
+			return
+		}
+		if typ>>4 != packetPublish || len(body) < 2 {
+			continue
+		}
+		n := int(binary.BigEndian.Uint16(body))
+		if len(body) < 2+n {
+			continue
+		}
+		topic, payload := string(body[2:2+n]), body[2+n:]
+		if qos := typ >> 1 & 0b11; qos > 0 && len(payload) >= 2 {
+			payload = payload[2:] // Packet id, not acknowledged as only QoS 0 is subscribed to
+		}
+		c.mu.Lock()
+		subs := c.subs
+		c.mu.Unlock()
+		for _, s := range subs {
+			if matchTopic(s.topic, topic) {
+				s.handler(topic, payload)
+			}
+		}
+	}
+}
+
+// ping sends a PINGREQ packet every half keep alive interval, until the connection is lost
+func (c *Client) ping(keepAlive time.Duration) {
+	t := time.NewTicker(keepAlive / 2)
+	defer t.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-t.C:
+			c.write(packetPingReq<<4, nil)
+		}
+	}
+}
+
+// write writes a packet of the type and flags given by header, with the body
+func (c *Client) write(header byte, body []byte) error {
+	p := []byte{header}
+	n := len(body)
+	for {
+		b := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			b |= 0x80
+		}
+		p = append(p, b)
+		if n == 0 {
+			break
+		}
+	}
+	p = append(p, body...)
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	_, err := c.conn.Write(p)
+	return err
+}
+
+// readPacket reads a packet, returning its first byte (type and flags) and body
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	typ, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	n, mul := 0, 1
+	for i := 0; ; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		n += int(b&0x7f) * mul
+		mul *= 128
+		if b&0x80 == 0 {
+			break
+		}
+		if i == 3 {
+			return 0, nil, errors.New("Malformed packet length")
+		}
+	}
+	body := make([]byte, n)
+	_, err = io.ReadFull(r, body)
+	return typ, body, err
+}
+
+// appendUint16 appends the value to b, big endian
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+// appendString appends the string to b, prefixed by its length as MQTT encodes strings
+func appendString(b []byte, s string) []byte {
+	b = appendUint16(b, uint16(len(s)))
+	return append(b, s...)
+}
+
+// matchTopic returns true if the topic matches the filter, which may have the wildcards + and #
+func matchTopic(filter, topic string) bool {
+	f, t := strings.Split(filter, "/"), strings.Split(topic, "/")
+	for i, level := range f {
+		if level == "#" {
+			return true
+		}
+		if i >= len(t) || level != "+" && level != t[i] {
+			return false
+		}
+	}
+	return len(f) == len(t)
+}
@@ -0,0 +1,99 @@
+// Package mqtt shows the payloads of MQTT topics on the display, e.g. states of Home Assistant
+// entities or readings of IoT sensors. Each topic subscribed to is mapped to a region of a row, and
+// its payloads are rendered there by a template, with JSON payloads decoded for the template, e.g.
+//
+//	c, err := mqtt.Dial("broker:1883", mqtt.Options{ClientID: "lcd"})
+//	...
+//	err = mqtt.Show(c, lcd,
+//		mqtt.Mapping{Topic: "home/livingroom/temperature", Row: 0, Template: "Temp {{.}}C"},
+//		mqtt.Mapping{Topic: "zigbee2mqtt/door", Row: 1, Template: "Door {{if .contact}}closed{{else}}open{{end}}"},
+//	)
+//
+// A minimal MQTT 3.1.1 client, subscribing with QoS 0, is included to keep the driver free of
+// dependencies; any other client can be used through the Subscriber interface
+package mqtt
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/hossner/go-st7066u"
+)
+
+// Subscriber is an MQTT client subscribing to topics, e.g. a Client, or an adapter of another client
+type Subscriber interface {
+	// Subscribe calls handler with the topic and payload of each message published to the topic,
+	// which may have the wildcards + and #
+	Subscribe(topic string, handler func(topic string, payload []byte)) error
+}
+
+// Mapping maps a topic to a region of a row of the display
+type Mapping struct {
+	Topic    string // Topic subscribed to, which may have the wildcards + and #
+	Row, Col uint8  // Start of the region
+	Width    uint8  // Width of the region, to the end of the row if 0
+	Template string // text/template rendering the payload, the payload as is if empty. See func Show
+}
+
+// Show subscribes to the topics of the mappings, and shows each payload received in the region of
+// its mapping, replacing what was shown there. The payload is rendered by the template of the
+// mapping, with the payload decoded as the data if it is JSON (e.g. {{.temperature}} for an object,
+// or {{printf "%.1f" .}} for a number), and as a string otherwise. The first line of the output is
+// shown, truncated to the width of the region. If a template fails, the payload is shown as is. An
+// error is returned if a template can't be parsed or a topic can't be subscribed to
+func Show(sub Subscriber, dev *st7066u.Device, mappings ...Mapping) error {
+	tmpls := make([]*template.Template, len(mappings))
+	for i, m := range mappings {
+		if m.Template == "" {
+			continue
+		}
+		t, err := template.New(m.Topic).Parse(m.Template)
+		if err != nil {
+			return err
+		}
+		tmpls[i] = t
+	}
+	_, cols := dev.Size()
+	for i, m := range mappings {
+		m, t := m, tmpls[i]
+		width := m.Width
+		if width == 0 || int(m.Col)+int(width) > int(cols) {
+			width = cols - m.Col
+		}
+		err := sub.Subscribe(m.Topic, func(topic string, payload []byte) {
+			text := []rune(render(t, payload))
+			if len(text) > int(width) {
+				text = text[:width]
+			}
+			line := string(text) + strings.Repeat(" ", int(width)-len(text))
+			dev.Update(func(f *st7066u.Frame) { f.PrintAt(m.Row, m.Col, line) })
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render returns the first line of the payload rendered by the template, or the payload as is if
+// there is no template or it fails
+func render(t *template.Template, payload []byte) string {
+	text := string(payload)
+	if t != nil {
+		var data interface{} = text
+		var v interface{}
+		if json.Unmarshal(payload, &v) == nil {
+			data = v
+		}
+		var b bytes.Buffer
+		if t.Execute(&b, data) == nil {
+			text = b.String()
+		}
+	}
+	if i := strings.IndexAny(text, "\r\n"); i >= 0 {
+		text = text[:i]
+	}
+	return text
+}
@@ -0,0 +1,90 @@
+//go:build !linux && !tinygo
+// +build !linux,!tinygo
+
+package st7066u
+
+import (
+	"errors"
+	"os"
+)
+
+// pinState is the level read from an input pin
+type pinState uint8
+
+// Levels of an input pin
+const (
+	pinLow pinState = iota
+	pinHigh
+)
+
+// NoPin is used instead of a pin number for the L pin, as with rpio
+const NoPin uint8 = 0xff
+
+// errNotLinux is returned by what requires the GPIO pins of a Raspberry Pi, on other platforms than
+// Linux
+var errNotLinux = errors.New("GPIO pins are only supported on Linux, use NewSimulated or NewFromPins")
+
+// gpioBank isn't available on other platforms than Linux
+type gpioBank struct{}
+
+// New isn't supported on other platforms than Linux, where rpio maps the GPIO of the Raspberry Pi,
+// but is kept so that programs using it build, e.g. to run them against the simulator during
+// development. An error is always returned
+func New(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, pinRS, pinE, pinL uint8, pins ...uint8) (*Device, error) {
+	return nil, errNotLinux
+}
+
+// NewFromOpenedGPIO isn't supported on other platforms than Linux, see New
+func NewFromOpenedGPIO(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, pinRS, pinE, pinL uint8, pins ...uint8) (*Device, error) {
+	return nil, errNotLinux
+}
+
+// NewDualController isn't supported on other platforms than Linux, see New
+func NewDualController(mode uint8, pinRS, pinE1, pinE2, pinL uint8, pins ...uint8) (*Device, error) {
+	return nil, errNotLinux
+}
+
+// NewFromProfile isn't supported on other platforms than Linux, see New
+func NewFromProfile(profile string, charSym uint8, mode uint8, pinRS, pinE, pinL uint8, pins ...uint8) (*Device, error) {
+	return nil, errNotLinux
+}
+
+// closeGPIO releases what the device uses of the GPIO, as the last step of Close. Nothing on other
+// platforms than Linux
+func (l *Device) closeGPIO() {}
+
+// SetRWPin isn't supported on other platforms than Linux, see New
+func (l *Device) SetRWPin(pinRW uint8) error {
+	return errNotLinux
+}
+
+// SetRGBPins isn't supported on other platforms than Linux, see New
+func (l *Device) SetRGBPins(pinR, pinG, pinB uint8, activeLow bool) error {
+	return errNotLinux
+}
+
+// SetBrightness isn't supported on other platforms than Linux, see New
+func (l *Device) SetBrightness(level float64) error {
+	return errNotLinux
+}
+
+// SetContrastPWM isn't supported on other platforms than Linux, see New
+func (l *Device) SetContrastPWM(pinV0 uint8) error {
+	return errNotLinux
+}
+
+// hasPwmBacklight reports if the brightness of the backlight can be set
+func (l *Device) hasPwmBacklight() bool {
+	return false
+}
+
+// setPwmDuty sets the duty of the L pin. Never used on other platforms than Linux
+func (l *Device) setPwmDuty(duty uint32) {}
+
+// openI2C isn't supported on other platforms than Linux, where there is no /dev/i2c-N
+func openI2C(bus int, addr uint8) (*os.File, error) {
+	return nil, errors.New("I2C devices are only supported on Linux")
+}
+
+// write implements nothing, see type gpioBank
+func (b *gpioBank) write(data uint8) {}
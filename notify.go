@@ -0,0 +1,149 @@
+package st7066u
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// notification is a message waiting to be shown, see func Notify
+type notification struct {
+	msg      string
+	d        time.Duration
+	priority int
+}
+
+// Notify shows the message over what is shown for the duration d, and then restores the previous
+// content of the display. The message is word wrapped and centered, and lines that don't fit are
+// dropped. Notifications given while one is shown are queued, and shown in order of priority
+// (highest first), and of arrival for the same priority; the previous content is restored when the
+// queue is empty. Notify doesn't wait for the message to be shown. Any changes made by others while
+// a message is shown are overwritten. Showing a message wakes the display, see SetIdleTimeout. See
+// also func SetNotifyBlink. Notify does nothing once the device is closed
+func (l *Device) Notify(msg string, d time.Duration, priority int) {
+	l.nmu.Lock()
+	defer l.nmu.Unlock()
+	l.mu.Lock()
+	closed := l.closed
+	l.mu.Unlock()
+	if closed {
+		return
+	}
+	l.notes = append(l.notes, notification{msg: msg, d: d, priority: priority})
+	sort.SliceStable(l.notes, func(i, j int) bool { return l.notes[i].priority > l.notes[j].priority })
+	if !l.notifying {
+		l.notifying = true
+		l.notifyStop, l.notifyDone = make(chan struct{}), make(chan struct{})
+		go l.showNotifications(l.notifyStop, l.notifyDone)
+	}
+}
+
+// SetNotifyBlink sets if the backlight blinks three times when a notification is shown, see func
+// Notify. Off by default
+func (l *Device) SetNotifyBlink(on bool) {
+	l.nmu.Lock()
+	l.notifyBlink = on
+	l.nmu.Unlock()
+}
+
+// stopNotifications drops the notifications queued, and stops the goroutine showing them, if any,
+// waiting for it to end
+func (l *Device) stopNotifications() {
+	l.nmu.Lock()
+	stop, done := l.notifyStop, l.notifyDone
+	l.notes, l.notifyStop, l.notifyDone = nil, nil, nil
+	l.nmu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
+// showNotifications shows the queued notifications one at a time, until the queue is empty, and then
+// restores the content shown before the first one. It ends early, leaving the notification shown,
+// when stop is closed
+func (l *Device) showNotifications(stop, done chan struct{}) {
+	defer close(done)
+	var saved *Frame
+	l.Update(func(f *Frame) {
+		saved = f.clone()
+	})
+	for {
+		l.nmu.Lock()
+		if len(l.notes) == 0 {
+			// Restored before a notification given meanwhile can start another goroutine saving the
+			// content, which would otherwise save this notification as the previous content
+			l.Update(func(f *Frame) {
+				f.copyFrom(saved)
+			})
+			l.notifying = false
+			l.nmu.Unlock()
+			return
+		}
+		n, blink := l.notes[0], l.notifyBlink
+		l.notes = l.notes[1:]
+		l.nmu.Unlock()
+		l.Wake()
+		l.Update(func(f *Frame) { f.printMessage(n.msg) })
+		shown := time.Now()
+		if blink {
+			l.blinkBacklight(3, time.Millisecond*300, stop)
+		}
+		timer := time.NewTimer(n.d - time.Since(shown))
+		select {
+		case <-stop:
+			timer.Stop()
+			l.nmu.Lock()
+			l.notifying = false
+			l.nmu.Unlock()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// printMessage clears the frame and prints the message word wrapped and centered. Lines that don't
+// fit are dropped
+func (f *Frame) printMessage(msg string) {
+	lines := wrapWords(msg, int(f.cols))
+	f.Clear()
+	top := 0
+	if len(lines) < int(f.rows) {
+		top = (int(f.rows) - len(lines)) / 2
+	}
+	for i, line := range lines {
+		if top+i >= int(f.rows) {
+			break
+		}
+		f.PrintAt(uint8(top+i), uint8((int(f.cols)-len([]rune(line)))/2), line)
+	}
+}
+
+// wrapWords splits the text into lines of at most width runes, breaking at spaces and newlines,
+// and words longer than the width within the word
+func wrapWords(text string, width int) []string {
+	var lines []string
+	for _, para := range strings.Split(text, "\n") {
+		var line []rune
+		for _, word := range strings.Fields(para) {
+			w := []rune(word)
+			for len(w) > 0 {
+				if len(line) > 0 && len(line)+1+len(w) <= width {
+					line = append(append(line, ' '), w...)
+					w = nil
+					continue
+				}
+				if len(line) > 0 {
+					lines = append(lines, string(line))
+				}
+				n := len(w)
+				if n > width {
+					n = width
+				}
+				line, w = append([]rune(nil), w[:n]...), w[n:]
+			}
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
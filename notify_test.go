@@ -0,0 +1,39 @@
+package st7066u
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotifyRestores(t *testing.T) {
+	d, s := newTestDevice(t, "1602", BITMODE4)
+	d.PrintAt(0, 0, "base")
+	// Notifications given as the one shown ends must not be restored as the previous content
+	for i := 0; i < 20; i++ {
+		d.Notify("note", time.Millisecond*5, 0)
+		time.Sleep(time.Millisecond * time.Duration(4+i%3))
+	}
+	waitLines(t, s, "base            ", "                ")
+}
+
+func TestNotifyClose(t *testing.T) {
+	d, s := newTestDevice(t, "1602", BITMODE4)
+	d.SetNotifyBlink(true)
+	d.Notify("shown", time.Hour, 0)
+	d.Notify("queued", time.Hour, 0)
+	waitLines(t, s, "     shown      ", "                ")
+	closed := make(chan struct{})
+	go func() {
+		d.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close waits for the notifications")
+	}
+	d.Notify("late", time.Millisecond, 0)
+	d.Update(func(f *Frame) { f.Print("late") })
+	time.Sleep(time.Millisecond * 20)
+	checkLines(t, s, "", "")
+}
@@ -0,0 +1,75 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Languages written without spaces between words, and languages forming long compound words whose
+// meaning is mostly given by the end of the word, by ISO 639-1 code
+var (
+	unspacedLangs = []string{"ja", "zh", "th", "lo", "km", "my"}
+	compoundLangs = []string{"de", "nl", "sv", "da", "no", "nb", "nn", "fi", "et", "hu", "is"}
+)
+
+// minWordVisible is the part of a cut word that must be visible for the text to be truncated
+const minWordVisible = 2.0 / 3
+
+// DecideOverflow decides how the text is shown in a field of the width, following the policy of the
+// field. With OverflowAuto the text is truncated only if that loses no meaning, i.e. if what is cut
+// is only trailing spaces and punctuation, or the tail of the last word with most of the word still
+// visible. Otherwise it is shown as a marquee. lang is the language of the text, as an IETF language
+// tag (e.g. "de" or "pt-BR"), or "" if not known. In languages written without spaces, and in
+// languages forming long compound words (e.g. German), words are never truncated. Returns
+// OverflowNone if the text fits, whatever the policy
+func DecideOverflow(text, lang string, width uint8, policy Overflow) Overflow {
+	runes := []rune(text)
+	if len(runes) <= int(width) {
+		return OverflowNone
+	}
+	if policy != OverflowAuto {
+		return policy
+	}
+	cut := runes[width:]
+	if strings.TrimFunc(string(cut), isFiller) == "" {
+		return OverflowTruncate
+	}
+	base := strings.ToLower(strings.SplitN(strings.SplitN(lang, "-", 2)[0], "_", 2)[0])
+	if inLangs(base, unspacedLangs) || inLangs(base, compoundLangs) {
+		return OverflowMarquee
+	}
+	// The visible part must end inside the last word, with most of that word visible
+	start := int(width)
+	for start > 0 && !unicode.IsSpace(runes[start-1]) {
+		start--
+	}
+	end := int(width)
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+	if start == int(width) || strings.TrimFunc(string(runes[end:]), isFiller) != "" {
+		return OverflowMarquee
+	}
+	if float64(int(width)-start) < minWordVisible*float64(end-start) {
+		return OverflowMarquee
+	}
+	return OverflowTruncate
+}
+
+// isFiller reports if the rune carries no meaning at the end of a text
+func isFiller(r rune) bool {
+	return unicode.IsSpace(r) || unicode.IsPunct(r)
+}
+
+// inLangs reports if the language is one of langs
+func inLangs(lang string, langs []string) bool {
+	for _, l := range langs {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
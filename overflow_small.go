@@ -0,0 +1,17 @@
+//go:build st7066u_small
+// +build st7066u_small
+
+package st7066u
+
+// DecideOverflow returns OverflowNone if the text fits a field of the width, and the policy of the
+// field otherwise, truncating with OverflowAuto, as deciding from the content and language is left
+// out with the build tag st7066u_small, see the DecideOverflow of other builds
+func DecideOverflow(text, lang string, width uint8, policy Overflow) Overflow {
+	switch {
+	case len([]rune(text)) <= int(width):
+		return OverflowNone
+	case policy == OverflowAuto:
+		return OverflowTruncate
+	}
+	return policy
+}
@@ -0,0 +1,37 @@
+package st7066u
+
+import "time"
+
+// textPage is a page of text made by func Paginate, one line per row from the top
+type textPage []string
+
+// Render implements Screen
+func (p textPage) Render(f *Frame) {
+	for r, line := range p {
+		f.PrintAt(uint8(r), 0, line)
+	}
+}
+
+// Paginate word wraps the text to the width of the display, breaking at spaces and newlines, and
+// words longer than the width within the word, and splits the lines into pages of the rows of the
+// display, e.g. to show a long message with ShowPages
+func (l *Device) Paginate(text string) []Screen {
+	lines := wrapWords(text, int(l.cols))
+	var pages []Screen
+	for i := 0; i < len(lines); i += int(l.rows) {
+		end := i + int(l.rows)
+		if end > len(lines) {
+			end = len(lines)
+		}
+		pages = append(pages, textPage(lines[i:end]))
+	}
+	return pages
+}
+
+// ShowPages returns a ScreenManager showing the pages, e.g. made by Paginate, starting with the
+// first. The manager steps to the next page every interval, starting over after the last, or only
+// when Next or Previous is called if the interval is 0. Call Stop on the returned struct to stop
+// showing the pages
+func (l *Device) ShowPages(pages []Screen, interval time.Duration) *ScreenManager {
+	return l.Rotate(interval, pages...)
+}
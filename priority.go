@@ -0,0 +1,53 @@
+package st7066u
+
+import "time"
+
+// frameInterval is the time between the frames writing the changes held back by the frame budget,
+// see func SetFrameBudget
+const frameInterval = time.Millisecond * 20
+
+// region is a part of a row with a refresh priority, see func SetPriority
+type region struct {
+	row, col, width uint8
+	prio            int
+}
+
+// SetPriority sets the refresh priority of a region of a row, e.g. a clock or an alarm. When the
+// changes made by Update are written, regions with higher priority are written first, which matters
+// when the frame budget (see func SetFrameBudget) doesn't allow all changes to be written at once.
+// Default priority is 0. A region overlapping one set earlier takes precedence. See ClearPriorities
+func (l *Device) SetPriority(row, col, width uint8, priority int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.regions = append(l.regions, region{row: row, col: col, width: width, prio: priority})
+}
+
+// ClearPriorities removes the refresh priorities of all regions set by SetPriority, e.g. when
+// another screen is shown
+func (l *Device) ClearPriorities() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.regions = nil
+}
+
+// SetFrameBudget sets the max nr of bytes (characters and cursor moves) written by each call to
+// Update, to keep each update short on slow transports. Changes that don't fit the budget are held
+// back, and written by the following frames, every 20 ms or by the following updates, highest
+// priority first, splitting a row of changes if needed. At least one character is written by each
+// frame. 0, the default, means no limit
+func (l *Device) SetFrameBudget(bytes int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.budget = bytes
+}
+
+// priority returns the refresh priority of the character at row and col
+func (l *Device) priority(row, col uint8) int {
+	for i := len(l.regions) - 1; i >= 0; i-- {
+		r := l.regions[i]
+		if r.row == row && col >= r.col && int(col) < int(r.col)+int(r.width) {
+			return r.prio
+		}
+	}
+	return 0
+}
@@ -0,0 +1,72 @@
+package st7066u
+
+import (
+	"testing"
+	"time"
+)
+
+// waitLines waits up to a second for the simulator to show the lines
+func waitLines(t *testing.T, s *Simulator, lines ...string) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond * 5) {
+		ok := true
+		for r, line := range s.Lines() {
+			if r < len(lines) && line != lines[r] {
+				ok = false
+			}
+		}
+		if ok {
+			return
+		}
+	}
+	checkLines(t, s, lines...)
+}
+
+func TestFrameBudget(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget int
+	}{
+		{"run longer than the budget", 8},
+		{"budget of one byte", 1},
+		{"no budget", 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d, s := newTestDevice(t, "1602", BITMODE8)
+			d.SetFrameBudget(tt.budget)
+			d.Update(func(f *Frame) {
+				f.PrintAt(0, 0, "0123456789abcdef")
+				f.PrintAt(1, 0, "second row")
+			})
+			if tt.budget > 0 && s.Lines()[1] != "                " {
+				t.Errorf("Second row written within a budget of %d bytes", tt.budget)
+			}
+			waitLines(t, s, "0123456789abcdef", "second row      ")
+			checkShadow(t, d, s)
+		})
+	}
+}
+
+func TestPriority(t *testing.T) {
+	d, s := newTestDevice(t, "1602", BITMODE8)
+	d.SetFrameBudget(6)
+	d.SetPriority(1, 0, 5, 1)
+	d.Update(func(f *Frame) {
+		f.PrintAt(0, 0, "ticker")
+		f.PrintAt(1, 0, "12:00")
+	})
+	checkLines(t, s, "", "12:00")
+	waitLines(t, s, "ticker          ", "12:00           ")
+
+	d.ClearPriorities()
+	d.Update(func(f *Frame) {
+		f.PrintAt(0, 0, "TICKER")
+		f.PrintAt(1, 0, "12:01")
+	})
+	if got := s.Lines()[1]; got != "12:00           " {
+		t.Errorf("Row 1 written first without priorities: %q", got)
+	}
+	waitLines(t, s, "TICKER          ", "12:01           ")
+}
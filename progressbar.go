@@ -0,0 +1,172 @@
+package st7066u
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Brackets are the characters drawn before and after the bar of a ProgressBar
+type Brackets [2]rune
+
+// Bracket styles of a ProgressBar
+var (
+	BracketsNone   = Brackets{}
+	BracketsSquare = Brackets{'[', ']'}
+	BracketsAngle  = Brackets{'<', '>'}
+	BracketsPipe   = Brackets{'|', '|'}
+)
+
+// barPartial are the runes registered for the partially filled cells of a ProgressBar, with 1 to 4
+// of the 5 columns of dots filled
+var barPartial = [...]rune{'▎', '▍', '▋', '▊'}
+
+// ProgressBar is a horizontal progress bar on one row of the display, with an optional label before
+// and percentage after the bar. Each cell of the bar is filled in 5 steps, one per column of dots,
+// with the partially filled cell drawn by a user-defined character (registered as '▎', '▍', '▋' and
+// '▊', see RegisterGlyph). Only the cells that changed are written when the bar is updated. Use
+// func NewProgressBar to get a new struct
+type ProgressBar struct {
+	l  *Device
+	mu sync.Mutex
+	s  barState
+}
+
+// barState is what a ProgressBar draws
+type barState struct {
+	row, col, width uint8
+	label           string
+	brackets        Brackets
+	percentage      bool
+	percent         float64
+}
+
+// NewProgressBar returns a ProgressBar at the row and col, width cells wide including the label,
+// brackets and percentage. The bar is drawn at 0%, with square brackets and the percentage shown
+func (l *Device) NewProgressBar(row, col, width uint8) *ProgressBar {
+	p := &ProgressBar{
+		l: l,
+		s: barState{row: row, col: col, width: width, brackets: BracketsSquare, percentage: true},
+	}
+	p.draw()
+	return p
+}
+
+// Set sets the progress, from 0 to 100 percent
+func (p *ProgressBar) Set(percent float64) {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	p.mu.Lock()
+	p.s.percent = percent
+	p.mu.Unlock()
+	p.draw()
+}
+
+// SetLabel sets the label drawn before the bar, e.g. "CPU"
+func (p *ProgressBar) SetLabel(label string) {
+	p.mu.Lock()
+	p.s.label = label
+	p.mu.Unlock()
+	p.draw()
+}
+
+// SetBrackets sets the characters drawn before and after the bar, e.g. BracketsAngle. A zero rune is
+// not drawn
+func (p *ProgressBar) SetBrackets(b Brackets) {
+	p.mu.Lock()
+	p.s.brackets = b
+	p.mu.Unlock()
+	p.draw()
+}
+
+// ShowPercentage sets if the percentage is drawn after the bar, e.g. " 42%"
+func (p *ProgressBar) ShowPercentage(on bool) {
+	p.mu.Lock()
+	p.s.percentage = on
+	p.mu.Unlock()
+	p.draw()
+}
+
+// draw draws the progress bar as it is now
+func (p *ProgressBar) draw() {
+	p.mu.Lock()
+	s := p.s
+	p.mu.Unlock()
+	p.l.Update(func(f *Frame) { s.draw(f) })
+}
+
+// draw draws the progress bar in the frame, leaving the cursor of the frame where it was
+func (s barState) draw(f *Frame) {
+	if s.row >= f.rows || s.col >= f.cols {
+		return
+	}
+	width := int(s.width)
+	if int(s.col)+width > int(f.cols) {
+		width = int(f.cols) - int(s.col)
+	}
+	var text []rune
+	text = append(text, []rune(s.label)...)
+	if len(text) > 0 {
+		text = append(text, ' ')
+	}
+	suffix := ""
+	if s.percentage {
+		suffix = fmt.Sprintf("%3d%%", int(s.percent+0.5))
+	}
+	cells := width - len(text) - len([]rune(suffix))
+	for _, b := range s.brackets {
+		if b != 0 {
+			cells--
+		}
+	}
+	if cells < 1 {
+		return
+	}
+	f.eachDevice((*Device).registerBarGlyphs)
+	row, col := f.row, f.col
+	f.SetCursor(s.row, s.col)
+	for _, r := range text {
+		f.PrintRune(r)
+	}
+	if s.brackets[0] != 0 {
+		f.PrintRune(s.brackets[0])
+	}
+	filled := int(math.Round(s.percent / 100 * float64(cells) * 5))
+	for i := 0; i < cells; i++ {
+		switch n := filled - i*5; {
+		case n <= 0:
+			f.PrintByte(0x20)
+		case n >= 5:
+			f.PrintByte(vuFull)
+		default:
+			f.PrintRune(barPartial[n-1])
+		}
+	}
+	if s.brackets[1] != 0 {
+		f.PrintRune(s.brackets[1])
+	}
+	f.Print(suffix)
+	f.row, f.col = row, col
+}
+
+// registerBarGlyphs registers the glyphs of the partially filled cells of a ProgressBar, if not
+// registered already
+func (l *Device) registerBarGlyphs() {
+	if l.glyphs == nil {
+		l.glyphs = make(map[rune]Glyph)
+	}
+	for i, r := range barPartial {
+		if _, ok := l.glyphs[r]; ok {
+			continue
+		}
+		var g Glyph
+		for k := range g {
+			g[k] = 0x1f << uint(4-i) & 0x1f
+		}
+		l.glyphs[r] = g
+	}
+}
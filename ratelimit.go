@@ -0,0 +1,72 @@
+package st7066u
+
+import "time"
+
+// SetRateLimit limits how often updates (see func Update) are written to the display, e.g. to keep
+// a sensor loop updating at 1 kHz from saturating the bus and ghosting the display. The changes of
+// an update are held back for the debounce window, and the changes of any further updates made
+// meanwhile are coalesced with them, so that rapid successive updates of the same region are written
+// once, with the latest content. Writes are also at least minInterval apart, capping the refresh
+// rate, e.g. time.Second/20 for 20 updates per second. Held back changes are written by a timer,
+// and at once by Flush. 0 for both, the default, writes each update at once
+func (l *Device) SetRateLimit(minInterval, debounce time.Duration) {
+	l.do(func() {
+		l.minInterval, l.debounce = minInterval, debounce
+		if minInterval <= 0 && debounce <= 0 {
+			l.flushHeld()
+		}
+	})
+}
+
+// commitLimited commits the frame of an update, or holds it back as the target of the next write if
+// so set by the rate limit. It is called while holding the device
+func (l *Device) commitLimited(f *Frame) {
+	if l.minInterval <= 0 && l.debounce <= 0 {
+		l.commit(f)
+		return
+	}
+	now := time.Now()
+	if l.rateTimer == nil {
+		due := now.Add(l.debounce)
+		if next := l.lastCommit.Add(l.minInterval); next.After(due) {
+			due = next
+		}
+		if !due.After(now) {
+			l.lastCommit = now
+			l.commit(f)
+			return
+		}
+		l.rateTimer = time.AfterFunc(due.Sub(now), func() { l.do(l.flushHeld) })
+	}
+	l.target = f // Coalesced with the updates until the timer fires
+}
+
+// holdBudget holds the frame back as the target of the next write, as not all of its changes fit the
+// frame budget, and starts a timer writing the next frame of it after frameInterval, unless the
+// rate limit has started one already
+func (l *Device) holdBudget(f *Frame) {
+	l.target = f
+	if l.rateTimer == nil {
+		l.rateTimer = time.AfterFunc(frameInterval, func() { l.do(l.flushHeld) })
+	}
+}
+
+// flushHeld writes the changes held back by the rate limit or the frame budget, if any
+func (l *Device) flushHeld() {
+	if l.rateTimer == nil {
+		return
+	}
+	l.stopRateTimer()
+	if l.target != nil {
+		l.lastCommit = time.Now()
+		l.commit(l.target)
+	}
+}
+
+// stopRateTimer stops the timer writing the changes held back by the rate limit, if any
+func (l *Device) stopRateTimer() {
+	if l.rateTimer != nil {
+		l.rateTimer.Stop()
+		l.rateTimer = nil
+	}
+}
@@ -0,0 +1,174 @@
+package st7066u
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// pinRead is the time from E going high until the data pins are read
+const pinRead = time.Microsecond * 1
+
+// inPin is a pin that can also be read, as the data pins when the R/W pin is used
+type inPin interface {
+	Input()
+	Read() pinState
+}
+
+// errNoRW is returned when reading without an R/W pin
+var errNoRW = errors.New("Reading from the display requires the R/W pin, see SetRWPin")
+
+// setRWPin sets the pin used for the R/W pin, see SetRWPin
+func (l *Device) setRWPin(pinRW Pin) error {
+	if l.bus != nil {
+		return errors.New("The R/W pin is not supported for displays on a bus")
+	}
+	if l.i2c != nil {
+		return errNoGPIO
+	}
+	l.mu.Lock()
+	pins := l.pinDs
+	l.mu.Unlock()
+	for _, p := range pins {
+		if !readable(p) {
+			return errors.New("Reading from the display requires data pins that can be read, as rpio.Pin")
+		}
+	}
+	if err := claimPins(l, pinRW); err != nil {
+		return err
+	}
+	l.do(func() {
+		pinRW.Output()
+		pinRW.Low()
+		l.pinRW = pinRW
+	})
+	return nil
+}
+
+// readable reports if the pin, or the pin recorded if wrapped by a recording, can be read
+func readable(p Pin) bool {
+	if rp, ok := p.(*recPin); ok {
+		p = rp.Pin
+	}
+	_, ok := p.(inPin)
+	return ok
+}
+
+// ReadDDRAM reads the character code at the DDRAM address from the controller. With two controllers
+// the first controller is read, see Screenshot for reading all rows
+func (l *Device) ReadDDRAM(addr uint8) (byte, error) {
+	return l.readRAM(0x80 | addr&0x7f)
+}
+
+// ReadCGRAM reads the byte at the CGRAM address, i.e. one row of the user-defined characters, from
+// the controller
+func (l *Device) ReadCGRAM(addr uint8) (byte, error) {
+	return l.readRAM(0x40 | addr&0x3f)
+}
+
+// Screenshot reads what the controller(s) hold for the visible part of the display, and returns it
+// as the text of each row. User-defined characters are returned as the runes of the registered glyphs
+// loaded (see RegisterGlyph), or as '\x00' to '\x07' otherwise, and codes without a known rune as
+// '?'. The cursor is left where it was
+func (l *Device) Screenshot() ([]string, error) {
+	var lines []string
+	err := errNoRW
+	l.doWait(func() {
+		if l.pinRW == nil {
+			return
+		}
+		err = nil
+		addr, ctl := l.addr, l.ctl
+		lines = make([]string, l.rows)
+		for r := range lines {
+			var b strings.Builder
+			for c := uint8(0); c < l.cols; c++ {
+				if c == 0 || !l.geo.follows(c) {
+					l.setAddr(l.geo.cellAddr(uint8(r), c))
+				}
+				b.WriteRune(l.codeRune(l.readData()))
+			}
+			lines[r] = b.String()
+		}
+		l.restoreAddrs(addr, ctl)
+	})
+	return lines, err
+}
+
+// readRAM sets the DDRAM or CGRAM address with the instruction, and reads the byte there
+func (l *Device) readRAM(instruction uint8) (byte, error) {
+	var b byte
+	err := errNoRW
+	l.doWait(func() {
+		if l.pinRW == nil {
+			return
+		}
+		err = nil
+		addr, ctl := l.addr, l.ctl
+		l.selectCtl(0)
+		l.write(instruction, cmdInstruction)
+		b = l.readData()
+		l.restoreAddrs(addr, ctl)
+	})
+	return b, err
+}
+
+// readData reads the byte at the address counter of the selected controller, which then moves on
+func (l *Device) readData() byte {
+	b := l.read(cmdData)
+	l.addr[l.ctl] = nextAddr(l.addr[l.ctl], l.geo.twoLines, true)
+	return b
+}
+
+// read reads a byte from the selected controller; data at the address counter, or the busy flag and
+// address counter, depending on cmd
+func (l *Device) read(cmd uint8) byte {
+	if cmd == cmdData {
+		l.pinRS.High()
+	} else {
+		l.pinRS.Low()
+	}
+	l.pinRW.High()
+	for _, p := range l.pinDs {
+		p.(inPin).Input()
+	}
+	b := l.readPins()
+	if l.mode == BITMODE4 {
+		b = b<<4 | l.readPins()
+	}
+	for _, p := range l.pinDs {
+		p.Output()
+	}
+	l.pinRW.Low()
+	return b
+}
+
+// readPins toggles E of the selected controller, reading the data pins while E is high. In 4-bit
+// mode this reads one nibble
+func (l *Device) readPins() uint8 {
+	e := l.pinE
+	if l.ctl == 1 {
+		e = l.pinE2
+	}
+	var data uint8
+	e.High()
+	time.Sleep(pinRead)
+	for i, p := range l.pinDs {
+		if p.(inPin).Read() == pinHigh {
+			data |= 1 << i
+		}
+	}
+	e.Low()
+	time.Sleep(l.timing.EPulse)
+	return data
+}
+
+// restoreAddrs moves the cursor of each controller back to addr, leaving ctl selected
+func (l *Device) restoreAddrs(addr [2]uint8, ctl int) {
+	for c := 0; c < l.geo.controllers(); c++ {
+		if c != ctl {
+			l.setAddr(c, addr[c])
+		}
+	}
+	l.setAddr(ctl, addr[ctl])
+}
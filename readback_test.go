@@ -0,0 +1,25 @@
+package st7066u
+
+import "testing"
+
+// outPin is a Pin that can't be read, e.g. a pin of a port expander
+type outPin struct{ n int }
+
+func (outPin) High()   {}
+func (outPin) Low()    {}
+func (outPin) Output() {}
+
+func TestSetRWPin(t *testing.T) {
+	pins := []Pin{outPin{0}, outPin{1}, outPin{2}, outPin{3}}
+	d, err := NewFromPins(2, 16, DOTS5x8, BITMODE4, outPin{4}, outPin{5}, nil, pins...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	if err := d.setRWPin(outPin{6}); err == nil {
+		t.Error("No error setting the R/W pin with data pins that can't be read")
+	}
+	if _, err := d.ReadDDRAM(0); err != errNoRW {
+		t.Errorf("Reading without the R/W pin: got %v, want %v", err, errNoRW)
+	}
+}
@@ -0,0 +1,186 @@
+package st7066u
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CommandRecording holds the instruction and data bytes written to the display, with timestamps,
+// see func RecordCommands. Use WriteLog to save them, and Replay to write them to a display again
+type CommandRecording struct {
+	dev      *Device
+	mu       sync.Mutex
+	start    time.Time
+	dual     bool // Recorded with two controllers
+	commands []command
+}
+
+// command is one recorded byte
+type command struct {
+	at   time.Duration
+	data uint8
+	cmd  uint8 // cmdInstruction or cmdData
+	ctl  int   // The controller written to, -1 for all
+}
+
+// RecordCommands starts recording every instruction and data byte written to the display, with
+// timestamps, until Stop is called on the returned struct. Any previous recording is stopped. The
+// recording can be saved as a log with WriteLog, e.g. as a golden file for regression tests of
+// rendering code, or to analyze the timing offline. Nothing is recorded in bounded memory mode, see
+// SetBoundedMemory
+func (l *Device) RecordCommands() *CommandRecording {
+	r := &CommandRecording{dev: l, dual: l.pinE2 != nil}
+	l.do(func() {
+		r.start = time.Now()
+		l.cmdRec = nil
+		if !l.bounded {
+			l.cmdRec = r
+		}
+	})
+	return r
+}
+
+// Stop stops the recording
+func (r *CommandRecording) Stop() {
+	l := r.dev
+	if l == nil {
+		return // Read with ReadCommandLog
+	}
+	l.do(func() {
+		if l.cmdRec == r {
+			l.cmdRec = nil
+		}
+	})
+}
+
+// Len returns the nr of bytes recorded
+func (r *CommandRecording) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.commands)
+}
+
+// WriteLog writes the recorded bytes as a log, one byte per line, e.g. "I 01" for an instruction
+// (here Clear Display) and "D 48" for data (here 'H'), both in hex. With timestamps, each line starts
+// with the nr of nanoseconds since the recording started, e.g. "1520394 D 48"; leave them out to get
+// the same log for the same output, e.g. for golden files. With two controllers, each line ends with
+// the controller written to, "E1", "E2", or "E*" for both. Lines starting with # are comments
+func (r *CommandRecording) WriteLog(w io.Writer, timestamps bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := bufio.NewWriter(w)
+	b.WriteString("# go-st7066u commands")
+	if timestamps && !r.start.IsZero() {
+		b.WriteString(", recorded " + r.start.Format(time.RFC1123))
+	}
+	b.WriteByte('\n')
+	for _, c := range r.commands {
+		if timestamps {
+			fmt.Fprintf(b, "%d ", c.at.Nanoseconds())
+		}
+		kind := 'I'
+		if c.cmd == cmdData {
+			kind = 'D'
+		}
+		fmt.Fprintf(b, "%c %02x", kind, c.data)
+		if r.dual {
+			switch c.ctl {
+			case -1:
+				b.WriteString(" E*")
+			default:
+				fmt.Fprintf(b, " E%d", c.ctl+1)
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.Flush()
+}
+
+// ReadCommandLog reads a log written by WriteLog, with or without timestamps, to be replayed with
+// Replay
+func ReadCommandLog(rd io.Reader) (*CommandRecording, error) {
+	r := &CommandRecording{}
+	s := bufio.NewScanner(rd)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		var c command
+		if fields[0] != "I" && fields[0] != "D" {
+			ns, err := strconv.ParseInt(fields[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: invalid timestamp %q", n, fields[0])
+			}
+			c.at, fields = time.Duration(ns), fields[1:]
+		}
+		if len(fields) < 2 || len(fields) > 3 || fields[0] != "I" && fields[0] != "D" {
+			return nil, fmt.Errorf("Line %d: expected I or D and a byte", n)
+		}
+		if fields[0] == "D" {
+			c.cmd = cmdData
+		}
+		data, err := strconv.ParseUint(fields[1], 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: invalid byte %q", n, fields[1])
+		}
+		c.data = uint8(data)
+		if len(fields) == 3 {
+			r.dual = true
+			switch fields[2] {
+			case "E1":
+			case "E2":
+				c.ctl = 1
+			case "E*":
+				c.ctl = -1
+			default:
+				return nil, fmt.Errorf("Line %d: invalid controller %q", n, fields[2])
+			}
+		}
+		r.commands = append(r.commands, c)
+	}
+	return r, s.Err()
+}
+
+// Replay writes the recorded bytes to the display, as fast as possible, or with the timing of the
+// recording if realtime is true. It returns when all bytes have been written, holding the display
+// meanwhile. The content and cursor the device keeps track of are not updated, so a device used for
+// replays shouldn't be used otherwise, e.g. use one of NewSimulated to check the output of a
+// recording. Bytes recorded for the second controller are written to the first one if there is none
+func (l *Device) Replay(r *CommandRecording, realtime bool) {
+	r.mu.Lock()
+	commands := r.commands
+	r.mu.Unlock()
+	l.doWait(func() {
+		ctl, all := l.ctl, l.all
+		start := time.Now()
+		for _, c := range commands {
+			if realtime {
+				time.Sleep(c.at - time.Since(start))
+			}
+			l.ctl, l.all = 0, false
+			switch {
+			case l.pinE2 == nil:
+			case c.ctl < 0:
+				l.all = true
+			default:
+				l.ctl = c.ctl
+			}
+			l.write(c.data, c.cmd)
+		}
+		l.ctl, l.all = ctl, all
+	})
+}
+
+// add records a byte written to the display
+func (r *CommandRecording) add(data uint8, cmd uint8, ctl int) {
+	r.mu.Lock()
+	r.commands = append(r.commands, command{at: time.Since(r.start), data: data, cmd: cmd, ctl: ctl})
+	r.mu.Unlock()
+}
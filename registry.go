@@ -0,0 +1,85 @@
+package st7066u
+
+import (
+	"fmt"
+	"sync"
+)
+
+// pinsInUse keeps track of the pins used by open devices (and buses), so that a second Device can't
+// be set up on pins that another, possibly forgotten, Device is still writing to. addrsInUse does
+// the same for the addresses of I2C devices
+var (
+	pinsMu     sync.Mutex
+	pinsInUse  = make(map[Pin]interface{})
+	addrsInUse = make(map[i2cAddr]interface{})
+)
+
+// i2cAddr is the address of a device on an I2C bus
+type i2cAddr struct {
+	bus  int
+	addr uint8
+}
+
+// claimPins registers the pins as used by the owner, a Device or a Bus. An error is returned if any
+// of the pins is used by another open device, or used twice by the owner itself
+func claimPins(owner interface{}, pins ...Pin) error {
+	pinsMu.Lock()
+	defer pinsMu.Unlock()
+	seen := make(map[Pin]bool)
+	for _, p := range pins {
+		if seen[p] {
+			return fmt.Errorf("Pin %v is used more than once", p)
+		}
+		seen[p] = true
+		if o, ok := pinsInUse[p]; ok && o != owner {
+			return fmt.Errorf("Pin %v is already used by another device, close that device first", p)
+		}
+	}
+	for _, p := range pins {
+		pinsInUse[p] = owner
+	}
+	return nil
+}
+
+// claimI2C registers the addresses on the I2C bus as used by the owner, as claimPins does for pins
+func claimI2C(owner interface{}, bus int, addrs ...uint8) error {
+	pinsMu.Lock()
+	defer pinsMu.Unlock()
+	for _, a := range addrs {
+		if o, ok := addrsInUse[i2cAddr{bus, a}]; ok && o != owner {
+			return fmt.Errorf("I2C address %#02x on bus %d is already used by another device, close that device first", a, bus)
+		}
+	}
+	for _, a := range addrs {
+		addrsInUse[i2cAddr{bus, a}] = owner
+	}
+	return nil
+}
+
+// releaseI2C unregisters the addresses on the I2C bus used by the owner, e.g. when the device at an
+// address claimed can't be opened
+func releaseI2C(owner interface{}, bus int, addrs ...uint8) {
+	pinsMu.Lock()
+	defer pinsMu.Unlock()
+	for _, a := range addrs {
+		if addrsInUse[i2cAddr{bus, a}] == owner {
+			delete(addrsInUse, i2cAddr{bus, a})
+		}
+	}
+}
+
+// releasePins unregisters all pins and I2C addresses used by the owner
+func releasePins(owner interface{}) {
+	pinsMu.Lock()
+	defer pinsMu.Unlock()
+	for p, o := range pinsInUse {
+		if o == owner {
+			delete(pinsInUse, p)
+		}
+	}
+	for a, o := range addrsInUse {
+		if o == owner {
+			delete(addrsInUse, a)
+		}
+	}
+}
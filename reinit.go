@@ -0,0 +1,56 @@
+package st7066u
+
+// Reinit initializes the display again, rerunning function set, display control and entry mode with
+// the current settings, to recover a display corrupted by e.g. electrical noise without restarting
+// the program. If restore is true, what was written to the display, and the position of the cursor,
+// is restored. Otherwise the display is left cleared
+func (l *Device) Reinit(restore bool) {
+	l.do(func() { l.reinit(restore) })
+}
+
+// reinit initializes the display again, optionally restoring its content
+func (l *Device) reinit(restore bool) {
+	ddram, addr, ctl := l.ddram, l.addr, l.ctl
+	l.init()
+	l.applyBacklight()
+	if !restore {
+		return
+	}
+	l.rewrite(ddram, addr, ctl)
+}
+
+// refresh writes the mode, display control and entry mode instructions, and the content of the
+// display, again, without clearing the display in between
+func (l *Device) refresh() {
+	l.all = true
+	l.setMode()
+	l.write(l.entryMode, cmdInstruction)
+	l.all = false
+	l.writeDisplay()
+	l.applyBacklight()
+	l.rewrite(l.ddram, l.addr, l.ctl)
+}
+
+// rewrite writes all of ddram to the controllers of the display, and moves the cursor of each
+// controller to addr, leaving ctl selected
+func (l *Device) rewrite(ddram [2][0x80]byte, addr [2]uint8, ctl int) {
+	l.writeGlyphs()
+	n := l.geo.controllers()
+	for c := 0; c < n; c++ {
+		for _, r := range l.ddramRanges() {
+			l.setAddr(c, r[0])
+			for a := r[0]; a < r[1]; a++ {
+				l.writeData(ddram[c][a])
+			}
+		}
+	}
+	l.restoreAddrs(addr, ctl)
+}
+
+// ddramRanges returns the ranges [start, end) of valid DDRAM addresses
+func (l *Device) ddramRanges() [][2]uint8 {
+	if l.geo.twoLines {
+		return [][2]uint8{{0x00, 0x28}, {0x40, 0x68}}
+	}
+	return [][2]uint8{{0x00, 0x50}}
+}
@@ -0,0 +1,182 @@
+package st7066u
+
+import (
+	"errors"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// rgbFrame is the period of the software PWM of the RGB backlight, ~200 Hz
+const rgbFrame = time.Millisecond * 5
+
+// Color is a color of an RGB backlight, see func SetBacklightColor
+type Color struct {
+	R, G, B uint8
+}
+
+// Named colors of an RGB backlight, e.g. SetBacklightColor(Orange.RGB())
+var (
+	White   = Color{0xff, 0xff, 0xff}
+	Red     = Color{0xff, 0x00, 0x00}
+	Green   = Color{0x00, 0xff, 0x00}
+	Blue    = Color{0x00, 0x00, 0xff}
+	Yellow  = Color{0xff, 0xff, 0x00}
+	Cyan    = Color{0x00, 0xff, 0xff}
+	Magenta = Color{0xff, 0x00, 0xff}
+	Orange  = Color{0xff, 0x50, 0x00}
+	Purple  = Color{0x80, 0x00, 0xff}
+)
+
+// RGB returns the red, green and blue components of the color
+func (c Color) RGB() (r, g, b uint8) {
+	return c.R, c.G, c.B
+}
+
+// rgbChannel sets the duty of the red, green and blue LEDs of an RGB backlight, from 0 (off) to 255
+// (on)
+type rgbChannel interface {
+	set(duty [3]uint8) error
+	close()
+}
+
+// softRGB drives the three pins of an RGB backlight with software PWM. The goroutine doing so only
+// runs while a pin is neither fully on nor fully off
+type softRGB struct {
+	pins      [3]Pin
+	activeLow bool
+	duty      uint32 // Duty of the pins as 0x00RRGGBB, accessed atomically
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// setRGBPins sets the pins of an RGB backlight, see SetRGBPins
+func (l *Device) setRGBPins(pins [3]Pin, activeLow bool) error {
+	if l.backend == "simulator" {
+		return errors.New("RGB backlights are not simulated")
+	}
+	if l.i2c != nil {
+		return errNoGPIO
+	}
+	if err := claimPins(l, pins[:]...); err != nil {
+		return err
+	}
+	l.do(func() {
+		for _, p := range pins {
+			p.Output()
+		}
+		if l.rgb != nil {
+			l.rgb.close()
+		}
+		l.rgb = &softRGB{pins: pins, activeLow: activeLow}
+		l.applyBacklight()
+	})
+	return nil
+}
+
+// SetBacklightColor sets the color of an RGB backlight, see SetRGBPins and NewGroveRGB. Named colors
+// can be used as e.g. SetBacklightColor(Orange.RGB()). The backlight is still turned on and off with
+// LedOn
+func (l *Device) SetBacklightColor(r, g, b uint8) error {
+	err := errors.New("Backlight color requires an RGB backlight, see SetRGBPins")
+	l.doWait(func() {
+		if l.rgb == nil {
+			return
+		}
+		err = nil
+		l.color = Color{r, g, b}
+		l.applyBacklight()
+	})
+	return err
+}
+
+// BacklightColor returns the current color of the RGB backlight
+func (l *Device) BacklightColor() Color {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.color
+}
+
+// applyRGB sets the pins of the RGB backlight, if any, according to the current on/off state and
+// color, dimmed by the brightness
+func (l *Device) applyRGB() {
+	if l.rgb == nil {
+		return
+	}
+	var duty [3]uint8
+	if l.backlightOn() {
+		duty = [3]uint8{l.color.R, l.color.G, l.color.B}
+		for i := range duty {
+			duty[i] = uint8(float64(duty[i])*l.brightness + 0.5)
+		}
+	}
+	if l.rgb.set(duty) != nil {
+		l.busErrors++
+	}
+}
+
+// set implements rgbChannel, starting or stopping the software PWM as needed
+func (s *softRGB) set(duty [3]uint8) error {
+	atomic.StoreUint32(&s.duty, uint32(duty[0])<<16|uint32(duty[1])<<8|uint32(duty[2]))
+	static := true
+	for _, d := range duty {
+		static = static && (d == 0 || d == 0xff)
+	}
+	switch {
+	case static && s.stop != nil:
+		close(s.stop)
+		<-s.done
+		s.stop, s.done = nil, nil
+	case !static && s.stop == nil:
+		s.stop, s.done = make(chan struct{}), make(chan struct{})
+		go s.run(s.stop, s.done)
+	}
+	if static {
+		for i := range s.pins {
+			s.write(i, duty[i] != 0)
+		}
+	}
+	return nil
+}
+
+// run does the software PWM, one frame at a time, until stopped
+func (s *softRGB) run(stop, done chan struct{}) {
+	defer close(done)
+	order := []int{0, 1, 2}
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		v := atomic.LoadUint32(&s.duty)
+		duty := [3]uint8{uint8(v >> 16), uint8(v >> 8), uint8(v)}
+		sort.Slice(order, func(i, j int) bool { return duty[order[i]] < duty[order[j]] })
+		start := time.Now()
+		for i := range s.pins {
+			s.write(i, duty[i] != 0)
+		}
+		for _, i := range order {
+			if duty[i] == 0 || duty[i] == 0xff {
+				continue
+			}
+			time.Sleep(time.Until(start.Add(rgbFrame * time.Duration(duty[i]) / 0xff)))
+			s.write(i, false)
+		}
+		time.Sleep(time.Until(start.Add(rgbFrame)))
+	}
+}
+
+// close implements rgbChannel
+func (s *softRGB) close() {
+	s.set([3]uint8{})
+}
+
+// write lights the LED of the pin, or not
+func (s *softRGB) write(i int, lit bool) {
+	if lit != s.activeLow {
+		s.pins[i].High()
+	} else {
+		s.pins[i].Low()
+	}
+}
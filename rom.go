@@ -0,0 +1,188 @@
+package st7066u
+
+import (
+	"errors"
+	"sync"
+)
+
+// ROM is the character ROM of the display, deciding which runes can be printed and their codes. See
+// func SetROM
+type ROM uint8
+
+// ROMA00, ROMA02 and ROMCyrillic; the supported character ROMs
+const (
+	ROMA00      ROM = iota // ROM code A00, Japanese with katakana, the most common (default)
+	ROMA02                 // ROM code A02, European with Western European and some Cyrillic and Greek letters
+	ROMCyrillic            // English/Russian, as font table 2 of WS0010 OLEDs and the ROM of many Cyrillic displays
+)
+
+// romTable maps runes to the codes of a ROM, and codes back to runes, built on first use
+type romTable struct {
+	once  sync.Once
+	build func() map[rune]byte
+	codes map[rune]byte
+	runes map[byte]rune
+}
+
+// romTables are the tables of the ROMs other than A00, which uses charMap
+var romTables = map[ROM]*romTable{
+	ROMA02:      {build: a02Map},
+	ROMCyrillic: {build: cyrillicMap},
+}
+
+// SetROM sets the character ROM of the display, as given by its ROM code or font table, so that the
+// runes of the ROM print correctly, e.g. Russian and Ukrainian text with ROMCyrillic. Most displays
+// have a fixed ROM, given by their part number, but the WS0010 has several font tables, of which the
+// one of the ROM is selected (A00 and Cyrillic, see SetVariant). Letters missing from a ROM are printed
+// as letters that look the same where possible, e.g. Cyrillic А as Latin A, and lowercase Cyrillic
+// letters missing from ROMA02 as the uppercase letters. Characters already printed are not changed
+func (l *Device) SetROM(r ROM) error {
+	if r > ROMCyrillic {
+		return errors.New("Unknown character ROM")
+	}
+	var err error
+	l.doWait(func() {
+		if l.variant == WS0010 && r == ROMA02 {
+			err = errors.New("The WS0010 has no A02 font table")
+			return
+		}
+		l.rom = r
+		l.setFontTable()
+		if l.variant == WS0010 {
+			l.writeAll(l.functionSet)
+		}
+	})
+	return err
+}
+
+// setFontTable sets the font table bits of the function set instruction of the WS0010 as given by
+// the ROM, and clears them for the other controllers
+func (l *Device) setFontTable() {
+	l.functionSet &^= 0b11
+	if l.variant == WS0010 && l.rom == ROMCyrillic {
+		l.functionSet |= 0b10 // English/Russian
+	}
+}
+
+// MapRune sets the code printed for the rune, overriding the code of the ROM (see SetROM), e.g.
+// MapRune('°', 0xDF), MapRune('→', 0x7E) or MapRune('█', 0xFF) for the characters of the A00 ROM
+// that look like them. Codes 0 - 7 are the user-defined characters. Runes with a registered glyph
+// (see RegisterGlyph) are printed as the glyph. Characters already printed are not changed
+func (l *Device) MapRune(r rune, code uint8) {
+	l.do(func() {
+		if l.runeMap == nil {
+			l.runeMap = make(map[rune]uint8)
+		}
+		l.runeMap[r] = code
+	})
+}
+
+// romCode returns the code of the rune set by MapRune or in the ROM of the display, the code of '?'
+// if there is none
+func (l *Device) romCode(r rune) byte {
+	if c, ok := l.runeMap[r]; ok {
+		return c
+	}
+	t, ok := romTables[l.rom]
+	if !ok {
+		return runeToSt70660b(r)
+	}
+	t.init()
+	if c, ok := t.codes[r]; ok {
+		return c
+	}
+	return '?'
+}
+
+// romRune returns the rune of the code in the ROM of the display, the lowest rune in case of several
+// candidates. Codes of the user-defined characters are returned as is
+func (l *Device) romRune(code byte) rune {
+	t, ok := romTables[l.rom]
+	if !ok || code < 8 {
+		return st70660bToRune(code)
+	}
+	t.init()
+	if r, ok := t.runes[code]; ok {
+		return r
+	}
+	return '?'
+}
+
+// init builds the maps of the table, once
+func (t *romTable) init() {
+	t.once.Do(func() {
+		t.codes = t.build()
+		t.runes = make(map[byte]rune)
+		for r, c := range t.codes {
+			if p, ok := t.runes[c]; !ok || r < p {
+				t.runes[c] = r
+			}
+		}
+	})
+}
+
+// addRunes maps the runes of the string to consecutive codes from first. '\x00' skips a code
+func addRunes(m map[rune]byte, first byte, runes string) {
+	for i, r := range []rune(runes) {
+		if r != 0 {
+			m[r] = first + byte(i)
+		}
+	}
+}
+
+// addCyrillic maps the Cyrillic letters to the codes of the letters they look like, if not mapped
+func addCyrillic(m map[rune]byte, looks map[rune]rune) {
+	for r, like := range looks {
+		if _, ok := m[r]; !ok {
+			if c, ok := m[like]; ok {
+				m[r] = c
+			}
+		}
+	}
+}
+
+// cyrillicLatin are Cyrillic letters that look like Latin letters, or letters of the Cyrillic ROMs,
+// including Ukrainian letters
+var cyrillicLatin = map[rune]rune{
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T',
+	'Х': 'X', 'Ь': 'b', 'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x',
+	'І': 'I', 'і': 'i', 'Ї': 'I', 'ї': 'i', 'Є': 'E', 'є': 'e', 'Ґ': 'Г', 'ґ': 'г',
+}
+
+// a02Map returns the mapping of runes to the codes of ROM code A02
+func a02Map() map[rune]byte {
+	m := make(map[rune]byte)
+	for c := byte(0x20); c < 0x7f; c++ {
+		m[rune(c)] = c // ASCII, including \ and ~
+	}
+	m['⌂'] = 0x7f
+	addRunes(m, 0x80, "БДЖЗИЙЛПУЦЧШЩЪЫЭ")
+	addRunes(m, 0x90, "α♪ΓπΣσ♬τ\x00ΘΩδ∞♥ε∩")
+	addRunes(m, 0xa1, "¡¢£¤¥¦§\x00©ª«ЮЯ")
+	addRunes(m, 0xb0, "°±²³\x00µ¶·\x00¹º»¼½¾¿")
+	addRunes(m, 0xc0, "ÀÁÂÃÄÅÆÇÈÉÊËÌÍÎÏÐÑÒÓÔÕÖ×ΦÙÚÛÜÝÞß")
+	addRunes(m, 0xe0, "àáâãäåæçèéêëìíîïðñòóôõö÷φùúûüýþÿ")
+	m['Г'] = m['Γ']
+	m['Ф'] = m['Φ']
+	m['ф'] = m['φ']
+	addCyrillic(m, cyrillicLatin)
+	for r := 'а'; r <= 'я'; r++ {
+		addCyrillic(m, map[rune]rune{r: r - 'а' + 'А'}) // Lowercase letters missing as uppercase
+	}
+	addCyrillic(m, map[rune]rune{'ё': 'Е', 'Ё': 'Е', 'ґ': 'Г'})
+	return m
+}
+
+// cyrillicMap returns the mapping of runes to the codes of the English/Russian ROM
+func cyrillicMap() map[rune]byte {
+	m := make(map[rune]byte)
+	for r, c := range charMap {
+		if c < 0x80 {
+			m[r] = c
+		}
+	}
+	addRunes(m, 0xa0, "БГЁЖЗИЙЛПУФЧШЪЫЭЮЯбвгёжзийклмнптчшъыьэюя")
+	addRunes(m, 0xe0, "ДЦЩдфцщ")
+	addCyrillic(m, cyrillicLatin)
+	return m
+}
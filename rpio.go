@@ -0,0 +1,256 @@
+//go:build linux && !tinygo
+// +build linux,!tinygo
+
+package st7066u
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/stianeikeland/go-rpio"
+)
+
+// pinState is the level read from an input pin
+type pinState = rpio.State
+
+// Levels of an input pin
+const (
+	pinLow  = rpio.Low
+	pinHigh = rpio.High
+)
+
+// NoPin is used instead of a pin number for the L pin, when the backlight isn't controlled through a
+// GPIO pin, e.g. when it is tied straight to 5V
+const NoPin rpio.Pin = 0xff
+
+// New returns a Device struct used as a handler for the LCD display. Arguments are
+//
+//	nrOfRows:	(uint8) 1 or 2 rows LCD displayes are supported
+//	nrOfCols:	(uint8) Nr of columns in the display. 16 and 20 are common values
+//	charSym:	Symmetry of the characters on the LCD display. DOTS5x8 or DOTS5x11 are supported
+//	mode:		In which "mode" the display is connected (w/ 4 or 8 data wires). BITMODE4 and BITMODE8 are supported
+//	pinRS:		GPIO pin used for the RS (reset) pin on the LCD display
+//	pinE:		GPIO pin used for the E (enable) pin on the LCD display
+//	pinL:		GPIO pin used for the L (LED) pin on the LCD display, or NoPin if the backlight isn't controlled by a GPIO pin
+//	pins:		GPIO pins used for data, can be either 4 or 8 pins. Start with the lowest numbered pin on the LCD display (D0 or D4, depending on "mode" used)
+func New(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, pinRS, pinE, pinL rpio.Pin, pins ...rpio.Pin) (*Device, error) {
+	if err := validateSymm(nrOfRows, nrOfCols, charSym); err != nil {
+		return nil, err
+	}
+	return newRpio(true, newGeometry(nrOfRows, nrOfCols), charSym, mode, pinRS, pinE, pinL, pins)
+}
+
+// NewFromOpenedGPIO returns a Device as New does, but leaves the lifecycle of rpio to the caller;
+// rpio.Open must have been called before, and rpio.Close is not called when the device is closed.
+// Use this when other rpio based peripherals are used by the program, as their GPIO mapping would
+// otherwise be torn down when the device is closed
+func NewFromOpenedGPIO(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, pinRS, pinE, pinL rpio.Pin, pins ...rpio.Pin) (*Device, error) {
+	if err := validateSymm(nrOfRows, nrOfCols, charSym); err != nil {
+		return nil, err
+	}
+	return newRpio(false, newGeometry(nrOfRows, nrOfCols), charSym, mode, pinRS, pinE, pinL, pins)
+}
+
+// NewDualController returns a Device for a 40x4 display with two controllers, each showing 2 rows
+// and having its own E pin, presented as one display with rows 0 - 3. Arguments are as for New,
+// apart from
+//
+//	pinE1:		GPIO pin used for the E1 pin, enabling the controller of rows 0 and 1
+//	pinE2:		GPIO pin used for the E2 pin, enabling the controller of rows 2 and 3
+//
+// The controllers can only be used with 5x8 dot characters
+func NewDualController(mode uint8, pinRS, pinE1, pinE2, pinL rpio.Pin, pins ...rpio.Pin) (*Device, error) {
+	return newRpio(true, dualGeometry(), DOTS5x8, mode, pinRS, pinE1, pinL, pins, pinE2)
+}
+
+// NewFromProfile returns a Device for a display of a known geometry, given by the name of its
+// profile, handling the row addresses and any addressing quirks of the display. Built in profiles are
+//
+//	"0801":		8x1 display
+//	"1601":		16x1 display that is internally 8x2, i.e. col 8 - 15 are shown from the second line of the controller
+//	"1602":		16x2 display
+//	"2004":		20x4 display, where rows 2 and 3 continue rows 0 and 1 in the controller
+//	"4004":		40x4 display with two controllers, which requires two E pins; use NewDualController
+//
+// More profiles can be added with RegisterProfile. Other arguments are as for New
+func NewFromProfile(profile string, charSym uint8, mode uint8, pinRS, pinE, pinL rpio.Pin, pins ...rpio.Pin) (*Device, error) {
+	geo, err := profileGeometry(profile, charSym)
+	if err != nil {
+		return nil, err
+	}
+	if geo.controllers() > 1 {
+		return nil, errors.New("Profile " + profile + " has two controllers, use NewDualController")
+	}
+	return newRpio(true, geo, charSym, mode, pinRS, pinE, pinL, pins)
+}
+
+// newRpio returns a Device with the geometry using rpio pins, opening (and later closing) rpio if
+// open is true. The second E pin is provided for dual controller displays only
+func newRpio(open bool, geo geometry, charSym uint8, mode uint8, pinRS, pinE, pinL rpio.Pin, pins []rpio.Pin, pinE2 ...rpio.Pin) (*Device, error) {
+	if err := validatePinMode(mode, len(pins)); err != nil {
+		return nil, err
+	}
+	if open {
+		if err := rpio.Open(); err != nil {
+			return nil, err
+		}
+	}
+	ds := make([]Pin, len(pins))
+	for i, p := range pins {
+		ds[i] = p
+	}
+	claim := append([]Pin{pinRS, pinE}, ds...)
+	var l Pin = noPin{}
+	if pinL != NoPin {
+		l = pinL
+		claim = append(claim, pinL)
+	}
+	g := newDevice(geo, charSym, pinRS, pinE, l, ds)
+	g.rpio = open
+	if len(pinE2) > 0 {
+		g.pinE2 = pinE2[0]
+		claim = append(claim, pinE2[0])
+	}
+
+	if err := claimPins(g, claim...); err != nil {
+		if open {
+			rpio.Close()
+		}
+		return nil, err
+	}
+	if b, err := openBank(pins); err == nil {
+		g.bank = b
+	}
+	g.setDefaultMasks()
+	g.start()
+	return g, nil
+}
+
+// closeGPIO releases what the device uses of the GPIO, as the last step of Close
+func (l *Device) closeGPIO() {
+	if l.bank != nil && l.bus == nil {
+		l.bank.close()
+		l.bank = nil
+	}
+	if l.rpio {
+		rpio.Close()
+	}
+}
+
+// SetRWPin sets the GPIO pin used for the R/W pin of the display, instead of holding it low (to
+// gnd). This allows what the controller holds to be read back, see ReadDDRAM and Screenshot. Note
+// that the display then drives the data pins while being read, which on a 5V display requires level
+// shifters on the data pins of the Pi. Not supported for displays on a Bus, and an error is returned
+// for displays opened with NewFromPins whose data pins can't be read
+func (l *Device) SetRWPin(pinRW rpio.Pin) error {
+	return l.setRWPin(pinRW)
+}
+
+// SetRGBPins sets the GPIO pins driving the red, green and blue LEDs of the backlight, for displays
+// with separate R, G and B cathodes, see SetBacklightColor. With activeLow, an LED is lit while its
+// pin is low, as when the cathodes are connected to the pins directly; otherwise while it is high,
+// as when driven through transistors. The Pi has only two hardware PWM channels, so the colors are
+// mixed with software PWM; any GPIO pin can be used. The color is white until set
+func (l *Device) SetRGBPins(pinR, pinG, pinB rpio.Pin, activeLow bool) error {
+	return l.setRGBPins([3]Pin{pinR, pinG, pinB}, activeLow)
+}
+
+// SetBrightness sets the brightness of the backlight, from 0.0 (off) to 1.0 (full). This requires
+// the L pin to be one of the hardware PWM capable pins (12, 13, 18 or 19)
+func (l *Device) SetBrightness(level float64) error {
+	pwm, ok := l.pwmPin()
+	if !ok {
+		return errors.New("Brightness requires the L pin to be a hardware PWM pin")
+	}
+	if level < 0 {
+		level = 0
+	}
+	if level > 1 {
+		level = 1
+	}
+	l.do(func() {
+		if !l.pwmOn {
+			pwm.Pwm()
+			pwm.Freq(pwmFreq)
+			l.pwmOn = true
+		}
+		l.brightness = level
+		l.applyBacklight()
+	})
+	return l.saveValue(keyBrightness, strconv.FormatFloat(level, 'f', -1, 64))
+}
+
+// pwmContrast is a hardware PWM pin feeding V0 through an RC filter
+type pwmContrast struct {
+	pin rpio.Pin
+}
+
+// SetContrastPWM sets a hardware PWM capable pin (12, 13, 18 or 19) feeding V0 of the display
+// through an RC filter, e.g. 10 kOhm and 10 uF, as the source of the contrast, see SetContrast. The
+// pin must not be on the same PWM channel as the L pin, i.e. 12 and 18, and 13 and 19, can't be
+// combined
+func (l *Device) SetContrastPWM(pinV0 rpio.Pin) error {
+	if l.backend == "simulator" {
+		return errNoContrastSim
+	}
+	if l.i2c != nil {
+		return errNoGPIO
+	}
+	if !isPwmPin(pinV0) {
+		return errors.New("Contrast requires a hardware PWM pin")
+	}
+	if pwm, ok := l.pwmPin(); ok && pwmChannel(pwm) == pwmChannel(pinV0) {
+		return errors.New("The contrast and backlight pins must be on different PWM channels")
+	}
+	if err := claimPins(l, pinV0); err != nil {
+		return err
+	}
+	pinV0.Pwm()
+	pinV0.Freq(pwmFreq)
+	return l.setContrastChannel(pwmContrast{pin: pinV0})
+}
+
+// set implements contrastChannel. The contrast is highest at the lowest voltage
+func (c pwmContrast) set(level float64) error {
+	c.pin.DutyCycle(uint32((1-level)*pwmCycle+0.5), pwmCycle)
+	return nil
+}
+
+// close implements contrastChannel
+func (c pwmContrast) close() {}
+
+// pwmPin returns the L pin, if it is a hardware PWM capable rpio pin
+func (l *Device) pwmPin() (rpio.Pin, bool) {
+	p, ok := l.pinL.(rpio.Pin)
+	return p, ok && isPwmPin(p)
+}
+
+// hasPwmBacklight reports if the brightness of the backlight can be set
+func (l *Device) hasPwmBacklight() bool {
+	_, ok := l.pwmPin()
+	return ok
+}
+
+// isPwmPin reports if the pin is connected to one of the two hardware PWM channels
+func isPwmPin(p rpio.Pin) bool {
+	switch p {
+	case 12, 13, 18, 19:
+		return true
+	}
+	return false
+}
+
+// pwmChannel returns the hardware PWM channel of the PWM capable pin
+func pwmChannel(p rpio.Pin) int {
+	if p == 13 || p == 19 {
+		return 1
+	}
+	return 0
+}
+
+// setPwmDuty sets the duty of the L pin, if it is a hardware PWM capable pin
+func (l *Device) setPwmDuty(duty uint32) {
+	if pwm, ok := l.pwmPin(); ok {
+		pwm.DutyCycle(duty, pwmCycle)
+	}
+}
@@ -0,0 +1,59 @@
+package st7066u
+
+// PrintRTL prints the text right to left from the provided position, e.g. Hebrew, with the first
+// rune at col and each following rune to the left of the one before, by printing it in the decrement
+// entry mode of the controller. Runes are printed as Print does; the controller has no Hebrew
+// characters in its ROMs, so register glyphs for the letters (see RegisterGlyph), or map them to the
+// codes of a display with a Hebrew ROM (see MapRune). Text beyond the first column continues at the
+// end of the previous line, as the controller does. The text is printed in its logical order as it
+// is, without reordering runs of digits or Latin text, and without shaping; Arabic text must be given
+// as presentation forms (U+FB50 - U+FDFF and U+FE70 - U+FEFF) of the shape of each letter, e.g. as
+// shaped by golang.org/x/text or a shaping library, and their glyphs registered. The entry mode is
+// restored afterwards
+func (l *Device) PrintRTL(row, col uint8, text string) {
+	l.do(func() {
+		l.touch()
+		var codes []byte
+		for _, r := range text {
+			t := l.transliterate(r)
+			if t == "" {
+				t = string(r)
+			}
+			for _, r := range t {
+				codes = append(codes, l.encodeRune(r, nil))
+			}
+		}
+		l.setCursor(row, col)
+		mode := l.entryMode
+		l.entryMode = mode &^ 0b10 // Decrement
+		l.write(l.entryMode, cmdInstruction)
+		for _, c := range codes {
+			l.writeData(c)
+		}
+		l.entryMode = mode
+		l.write(mode, cmdInstruction)
+	})
+}
+
+// PrintRTL prints the text right to left from the provided position, with the first rune at col and
+// each following rune to the left of the one before, leaving the cursor of the frame where it was.
+// See Device.PrintRTL. Text beyond the first column is dropped
+func (f *Frame) PrintRTL(row, col uint8, text string) {
+	if row >= f.rows || col >= f.cols {
+		return
+	}
+	c := int(col)
+	for _, r := range text {
+		t := f.transliterate(r, row, uint8(c))
+		if t == "" {
+			t = string(r)
+		}
+		for _, r := range t {
+			if c < 0 {
+				return
+			}
+			f.cells[row][c] = f.encodeRune(r, row, uint8(c))
+			c--
+		}
+	}
+}
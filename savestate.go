@@ -0,0 +1,207 @@
+package st7066u
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SaveState writes what the display shows as text, e.g. to a file, to be restored with LoadState
+// after the program restarts: the content of the display data RAM, the user-defined characters, the
+// cursor, the display control and entry mode, and the backlight. Lines starting with # are comments
+func (l *Device) SaveState(w io.Writer) error {
+	var ddram [2][0x80]byte
+	var addr [2]uint8
+	var slots [8]glyphSlot
+	var ctl, nrOfSlots int
+	var displayCtl, entryMode uint8
+	var on bool
+	var brightness float64
+	l.doWait(func() {
+		ddram, addr, ctl, slots, nrOfSlots = l.ddram, l.addr, l.ctl, l.slots, l.nrOfSlots()
+		displayCtl, entryMode = l.displayCtl, l.entryMode
+		on, brightness = l.ledOn, l.brightness
+	})
+	b := bufio.NewWriter(w)
+	b.WriteString("# go-st7066u state\n")
+	fmt.Fprintf(b, "size %d %d\n", l.rows, l.cols)
+	for c := 0; c < l.geo.controllers(); c++ {
+		fmt.Fprintf(b, "ddram %d %s\n", c, hex.EncodeToString(ddram[c][:]))
+	}
+	fmt.Fprintf(b, "cursor %d %02x %02x\n", ctl, addr[0], addr[1])
+	fmt.Fprintf(b, "mode %02x %02x\n", displayCtl, entryMode)
+	for i, s := range slots[:nrOfSlots] {
+		var kind string
+		switch {
+		case s.fixed:
+			kind = "fixed"
+		case s.inverse:
+			kind = "inverse"
+		case s.used:
+			kind = "used"
+		default:
+			continue
+		}
+		fmt.Fprintf(b, "glyph %d %s %U %s\n", i, kind, s.r, hex.EncodeToString(s.g[:]))
+	}
+	fmt.Fprintf(b, "backlight %t %g\n", on, brightness)
+	return b.Flush()
+}
+
+// LoadState reads a state written by SaveState and shows it on the display, writing its content,
+// user-defined characters, cursor, display control and entry mode, and backlight, e.g. to resume
+// showing what was shown before the program restarted. What the state doesn't hold is left as it is,
+// except the content, which is blank where missing. Registered glyphs of the state are registered
+// again, if not registered already. An error is returned, without changing the display, if the
+// state is invalid or of a display of another size
+func (l *Device) LoadState(r io.Reader) error {
+	var ddram [2][0x80]byte
+	for c := range ddram {
+		for a := range ddram[c] {
+			ddram[c][a] = 0x20
+		}
+	}
+	var addr [2]uint8
+	var slots [8]glyphSlot
+	ctl := 0
+	var displayCtl, entryMode uint8
+	on, brightness := true, 1.0
+	var hasMode, hasBacklight bool
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Fields(line)
+		var err error
+		switch {
+		case f[0] == "size" && len(f) == 3:
+			if f[1] != strconv.Itoa(int(l.rows)) || f[2] != strconv.Itoa(int(l.cols)) {
+				return fmt.Errorf("Line %d: the state is of a %sx%s display", n, f[2], f[1])
+			}
+		case f[0] == "ddram" && len(f) == 3:
+			var c int
+			c, err = parseIndex(f[1], l.geo.controllers())
+			if err == nil {
+				err = parseHex(f[2], ddram[c][:])
+			}
+		case f[0] == "cursor" && len(f) == 4:
+			ctl, err = parseIndex(f[1], l.geo.controllers())
+			for i := range addr {
+				if err == nil {
+					addr[i], err = parseByte(f[2+i])
+					addr[i] &= 0x7f
+				}
+			}
+		case f[0] == "mode" && len(f) == 3:
+			displayCtl, err = parseByte(f[1])
+			if err == nil {
+				entryMode, err = parseByte(f[2])
+			}
+			if err == nil && (displayCtl&^0x07 != 0x08 || entryMode&^0x03 != 0x04) {
+				err = fmt.Errorf("invalid mode %q %q", f[1], f[2])
+			}
+			hasMode = true
+		case f[0] == "glyph" && len(f) == 5:
+			err = parseSlot(f[1:], slots[:l.nrOfSlots()])
+		case f[0] == "backlight" && len(f) == 3:
+			on, err = strconv.ParseBool(f[1])
+			if err == nil {
+				brightness, err = strconv.ParseFloat(f[2], 64)
+			}
+			if err == nil && (brightness < 0 || brightness > 1) {
+				err = fmt.Errorf("invalid brightness %q", f[2])
+			}
+			hasBacklight = true
+		default:
+			return fmt.Errorf("Line %d: unknown or invalid line %q", n, line)
+		}
+		if err != nil {
+			return fmt.Errorf("Line %d: %v", n, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	l.do(func() {
+		l.ddram, l.addr, l.ctl, l.slots = ddram, addr, ctl, slots
+		if hasMode {
+			l.displayCtl, l.entryMode = displayCtl, entryMode
+		}
+		if hasBacklight {
+			l.ledOn, l.brightness = on, brightness
+		}
+		l.target = nil
+		for _, s := range slots {
+			if !s.used || s.inverse {
+				continue
+			}
+			if l.glyphs == nil {
+				l.glyphs = make(map[rune]Glyph)
+			}
+			if _, ok := l.glyphs[s.r]; !ok {
+				l.glyphs[s.r] = s.g
+			}
+		}
+		l.refresh()
+	})
+	return nil
+}
+
+// parseSlot parses the slot, kind, rune and pattern of a glyph of a state into the slots
+func parseSlot(f []string, slots []glyphSlot) error {
+	i, err := parseIndex(f[0], len(slots))
+	if err != nil {
+		return err
+	}
+	s := &slots[i]
+	switch f[1] {
+	case "fixed":
+		s.fixed = true
+	case "inverse":
+		s.used, s.inverse = true, true
+	case "used":
+		s.used = true
+	default:
+		return fmt.Errorf("invalid glyph kind %q", f[1])
+	}
+	r, err := strconv.ParseUint(strings.TrimPrefix(f[2], "U+"), 16, 32)
+	if err != nil || !strings.HasPrefix(f[2], "U+") {
+		return fmt.Errorf("invalid rune %q", f[2])
+	}
+	s.r = rune(r)
+	return parseHex(f[3], s.g[:])
+}
+
+// parseIndex parses a decimal index below n
+func parseIndex(s string, n int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 || i >= n {
+		return 0, fmt.Errorf("invalid index %q", s)
+	}
+	return i, nil
+}
+
+// parseByte parses a byte in hex
+func parseByte(s string) (uint8, error) {
+	b, err := strconv.ParseUint(s, 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte %q", s)
+	}
+	return uint8(b), nil
+}
+
+// parseHex parses exactly len(b) bytes in hex into b
+func parseHex(s string, b []byte) error {
+	if hex.DecodedLen(len(s)) != len(b) {
+		return fmt.Errorf("expected %d bytes in hex", len(b))
+	}
+	if _, err := hex.Decode(b, []byte(s)); err != nil {
+		return fmt.Errorf("invalid hex %q", s)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package st7066u
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// keyScene is the prefix of the keys of the states persisted by a Scene
+const keyScene = "scene."
+
+// Stateful is a screen manager, widget or animation whose state can be saved as text and restored,
+// e.g. a *ScreenManager, *Menu, *List, *TextInput, *Countdown or *Player, see type Scene
+type Stateful interface {
+	State() string
+	SetState(state string) error
+}
+
+// Scene persists the state of the screen managers, widgets and animations of a program in a Store,
+// e.g. a FileStore, so that the program resumes showing the same screen, with the same items
+// selected, and the rotations and animations where they were, when restarted, e.g. a kiosk after a
+// power loss. The program creates its screens and widgets as usual, and adds them to the scene by
+// key, which restores their state. What they show is not persisted, as they draw it again, see also
+// func SaveState for the content of the display. Use func NewScene to get a new struct
+type Scene struct {
+	store    Store
+	interval time.Duration
+	mu       sync.Mutex
+	keys     []string
+	items    map[string]Stateful
+	saved    map[string]string // The state last saved of each item
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScene returns a Scene persisting in the store, saving the states of its items that changed every
+// interval, and when stopped. An interval of 0 saves only when Save or Stop is called. Call Stop on
+// the returned struct when the program shuts down
+func NewScene(s Store, interval time.Duration) *Scene {
+	sc := &Scene{
+		store:    s,
+		interval: interval,
+		items:    make(map[string]Stateful),
+		saved:    make(map[string]string),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go sc.run()
+	return sc
+}
+
+// Add adds the item by key, restoring the state saved for the key by an earlier run of the program,
+// if any. The keys must be unique and stay the same across runs. An error is returned if the key is
+// in use, or if the state saved can't be loaded or restored, e.g. as the screens have changed since;
+// the item is added anyway, to be saved from now on
+func (sc *Scene) Add(key string, item Stateful) error {
+	sc.mu.Lock()
+	if _, ok := sc.items[key]; ok {
+		sc.mu.Unlock()
+		return fmt.Errorf("Key %q is in use", key)
+	}
+	sc.keys = append(sc.keys, key)
+	sc.items[key] = item
+	sc.mu.Unlock()
+	state, err := loadValue(sc.store, keyScene+key)
+	if err != nil || state == "" {
+		return err
+	}
+	if err = item.SetState(state); err != nil {
+		return fmt.Errorf("Restoring %q: %v", key, err)
+	}
+	sc.mu.Lock()
+	sc.saved[key] = state
+	sc.mu.Unlock()
+	return nil
+}
+
+// Save saves the states of the items that changed since last saved. The first error of the Store is
+// returned, if any
+func (sc *Scene) Save() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	var first error
+	for _, key := range sc.keys {
+		state := sc.items[key].State()
+		if saved, ok := sc.saved[key]; ok && saved == state {
+			continue
+		}
+		if err := sc.store.Save(keyScene+key, []byte(state)); err != nil {
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		sc.saved[key] = state
+	}
+	return first
+}
+
+// Stop stops saving at the interval, and saves the states that changed a last time. Errors are
+// dropped; call Save before Stop to handle them
+func (sc *Scene) Stop() {
+	sc.stopOnce.Do(func() { close(sc.stop) })
+	<-sc.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (sc *Scene) Done() <-chan struct{} {
+	return sc.done
+}
+
+// run saves the states every interval until stopped, and then a last time
+func (sc *Scene) run() {
+	defer close(sc.done)
+	var tick <-chan time.Time
+	if sc.interval > 0 {
+		ticker := time.NewTicker(sc.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-sc.stop:
+			sc.Save()
+			return
+		case <-tick:
+			sc.Save()
+		}
+	}
+}
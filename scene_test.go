@@ -0,0 +1,83 @@
+package st7066u
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// sceneRun runs the program of a test of Scene once, persisting in the store, with the screens, the
+// menu and the animation on a simulated display each, and returns the simulators and the items added
+func sceneRun(t *testing.T, store Store) ([3]*Simulator, *Scene, *ScreenManager, *Menu, *Player) {
+	t.Helper()
+	var d [3]*Device
+	var s [3]*Simulator
+	for i := range d {
+		d[i], s[i] = newTestDevice(t, "1602", BITMODE4)
+	}
+	screen := func(text string) Screen {
+		return ScreenFunc(func(f *Frame) { f.Print(text) })
+	}
+	m := d[0].Rotate(time.Hour, screen("one"), screen("two"), screen("three"))
+	t.Cleanup(m.Stop)
+	menu := d[1].NewMenu(&MenuItem{Label: "Play"}, &MenuItem{Label: "Setup", Items: []*MenuItem{
+		{Label: "Volume"}, {Label: "Light"},
+	}})
+	p := d[2].Play(Animation{Loop: true, Duration: time.Hour, Frames: []AnimationFrame{
+		{Lines: []string{"a"}}, {Lines: []string{"b"}}, {Lines: []string{"c"}},
+	}})
+	t.Cleanup(p.Stop)
+	sc := NewScene(store, 0)
+	for key, item := range map[string]Stateful{"screens": m, "menu": menu, "spinner": p} {
+		if err := sc.Add(key, item); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s, sc, m, menu, p
+}
+
+// waitState waits up to a second for the item to have the state
+func waitState(t *testing.T, item Stateful, prefix string) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond * 5) {
+		if strings.HasPrefix(item.State(), prefix) {
+			return
+		}
+	}
+	t.Errorf("State %q, want %q", item.State(), prefix)
+}
+
+func TestScene(t *testing.T) {
+	store := NewMemoryStore()
+	_, sc, m, menu, p := sceneRun(t, store)
+	m.Show(2)
+	menu.Down()
+	menu.Select()
+	menu.Down()
+	p.SetState("1")
+	waitState(t, p, "1")
+	sc.Stop()
+
+	s, sc, m, menu, p := sceneRun(t, store)
+	defer sc.Stop()
+	if got := m.Current(); got != 2 {
+		t.Errorf("Screen %d restored, want 2", got)
+	}
+	if got := menu.State(); got != "1/1" {
+		t.Errorf("Menu state %q restored, want \"1/1\"", got)
+	}
+	waitState(t, p, "1")
+	waitLines(t, s[0], "three           ")
+	waitLines(t, s[1], " Volume         ", "→Light          ")
+	waitLines(t, s[2], "b               ")
+
+	if err := sc.Add("menu", menu); err == nil {
+		t.Error("No error adding a key in use")
+	}
+	if err := menu.SetState("0/1"); err == nil {
+		t.Error("No error restoring a menu item without items")
+	}
+	if err := m.SetState("5 false 0s"); err == nil {
+		t.Error("No error restoring a screen beyond the screens")
+	}
+}
@@ -0,0 +1,126 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Dimming is a period of the day with its own backlight brightness, see ScheduleBrightness
+type Dimming struct {
+	From       string  // Start of the period, e.g. "22:00"
+	To         string  // End of the period, e.g. "07:00", which is on the next day if before From
+	Brightness float64 // Brightness during the period, 0.0 - 1.0
+}
+
+// BrightnessSchedule sets the backlight brightness of a Device by the time of day. Use func
+// ScheduleBrightness to get a new struct
+type BrightnessSchedule struct {
+	dev      *Device
+	normal   float64
+	periods  []period
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// period is a Dimming parsed, with its start and end as minutes since midnight
+type period struct {
+	from, to   int
+	brightness float64
+}
+
+// ScheduleBrightness starts setting the backlight brightness by the local time of day; the
+// brightness of the period of the day, if any, and the normal brightness otherwise, e.g.
+//
+//	lcd.ScheduleBrightness(1, st7066u.Dimming{From: "22:00", To: "07:00", Brightness: 0.1})
+//
+// Where periods overlap, the first one given is used. The brightness is set at once, and then on
+// each minute boundary when it changes, so it may be changed by other means in between. This
+// requires the L pin to be a hardware PWM pin, see SetBrightness. Call Stop on the returned struct to
+// stop the schedule, leaving the brightness as is
+func (l *Device) ScheduleBrightness(normal float64, periods ...Dimming) (*BrightnessSchedule, error) {
+	if !l.hasPwmBacklight() {
+		return nil, errors.New("Brightness requires the L pin to be a hardware PWM pin")
+	}
+	s := &BrightnessSchedule{
+		dev:    l,
+		normal: normal,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	for _, d := range periods {
+		from, err := parseClock(d.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseClock(d.To)
+		if err != nil {
+			return nil, err
+		}
+		s.periods = append(s.periods, period{from: from, to: to, brightness: d.Brightness})
+	}
+	go s.run()
+	return s, nil
+}
+
+// Stop stops the schedule, leaving the brightness as is
+func (s *BrightnessSchedule) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (s *BrightnessSchedule) Done() <-chan struct{} {
+	return s.done
+}
+
+// run sets the brightness at once and on each minute boundary when it changes, until stopped
+func (s *BrightnessSchedule) run() {
+	defer close(s.done)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	last := -1.0
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-timer.C:
+		}
+		now := time.Now()
+		if b := s.target(now); b != last {
+			s.dev.SetBrightness(b)
+			last = b
+		}
+		timer.Reset(now.Truncate(time.Minute).Add(time.Minute).Sub(time.Now()))
+	}
+}
+
+// target returns the brightness for the time t; that of the first period it is within, or the
+// normal brightness
+func (s *BrightnessSchedule) target(t time.Time) float64 {
+	m := t.Hour()*60 + t.Minute()
+	for _, p := range s.periods {
+		in := m >= p.from && m < p.to
+		if p.to < p.from {
+			in = m >= p.from || m < p.to
+		}
+		if in {
+			return p.brightness
+		}
+	}
+	return s.normal
+}
+
+// parseClock returns the time of day given as "HH:MM" as minutes since midnight
+func parseClock(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("Time of day %q must be given as HH:MM", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,369 @@
+package st7066u
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Screen is a screen of the display, e.g. a page of a dashboard, shown by a ScreenManager
+type Screen interface {
+	// Render draws the screen in the frame, which is cleared. It is run while holding the device, so
+	// it must not call any methods of the Device
+	Render(f *Frame)
+}
+
+// ScreenFunc is a function drawing a screen, used as a Screen
+type ScreenFunc func(f *Frame)
+
+// Render calls the function
+func (fn ScreenFunc) Render(f *Frame) {
+	fn(f)
+}
+
+// Transition is how a ScreenManager switches from one screen to another
+type Transition int
+
+// Transitions between screens
+const (
+	TransitionNone  Transition = iota // The new screen replaces the old at once
+	TransitionSlide                   // The new screen slides in from the right, or from the left when going back
+)
+
+// ScreenManager shows one of a set of screens at a time, and handles switching between them,
+// optionally rotating them at an interval, and redrawing the screen shown when requested or at an
+// interval. Screens are drawn by a goroutine of the manager, and only the cells that changed are
+// written. Use func NewScreenManager to get a new struct
+type ScreenManager struct {
+	l          *Device
+	mu         sync.Mutex
+	screens    []Screen
+	current    int  // The screen to show
+	shown      int  // The screen shown, -1 if none
+	dir        int  // Direction of the last switch, 1 forward and -1 back, for transitions
+	redraw     bool // A redraw has been requested
+	refresh    time.Duration
+	rotation   time.Duration
+	paused     bool // The rotation is paused
+	transition Transition
+	duration   time.Duration // Duration of the transition
+	shownAt    time.Time     // When the screen shown was switched to
+	resume     time.Duration // Time of the rotation interval passed, for the screen switched to by SetState
+	drawnAt    time.Time
+	wake       chan struct{}
+	stop       chan struct{}
+	done       chan struct{}
+	stopOnce   sync.Once
+}
+
+// NewScreenManager returns a ScreenManager of the screens, showing the first one. Screens are only
+// drawn when switched to or when Redraw is called, until a refresh interval is set, see SetRefresh.
+// Call Stop on the returned struct to stop managing the display
+func (l *Device) NewScreenManager(screens ...Screen) *ScreenManager {
+	m := &ScreenManager{
+		l:        l,
+		screens:  screens,
+		shown:    -1,
+		dir:      1,
+		duration: time.Millisecond * 300,
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+// Add adds the screen after the screens of the manager
+func (m *ScreenManager) Add(s Screen) {
+	m.mu.Lock()
+	m.screens = append(m.screens, s)
+	m.mu.Unlock()
+	m.notify()
+}
+
+// Show switches to the screen of the index, if within the screens
+func (m *ScreenManager) Show(index int) {
+	m.mu.Lock()
+	if index >= 0 && index < len(m.screens) {
+		m.dir = 1
+		if index < m.current {
+			m.dir = -1
+		}
+		m.current, m.resume = index, 0
+	}
+	m.mu.Unlock()
+	m.notify()
+}
+
+// Next switches to the next screen, or the first after the last
+func (m *ScreenManager) Next() {
+	m.step(1)
+}
+
+// Previous switches to the previous screen, or the last before the first
+func (m *ScreenManager) Previous() {
+	m.step(-1)
+}
+
+// Current returns the index of the screen shown, or being switched to
+func (m *ScreenManager) Current() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.current
+}
+
+// Redraw draws the screen shown again, e.g. when the data it shows has changed. Redraws requested
+// before the screen is drawn are drawn once
+func (m *ScreenManager) Redraw() {
+	m.mu.Lock()
+	m.redraw = true
+	m.mu.Unlock()
+	m.notify()
+}
+
+// SetRefresh sets the interval at which the screen shown is drawn again, e.g. for screens showing
+// live data. 0, the default, draws screens only when switched to or when Redraw is called
+func (m *ScreenManager) SetRefresh(interval time.Duration) {
+	m.mu.Lock()
+	m.refresh = interval
+	m.mu.Unlock()
+	m.notify()
+}
+
+// SetRotation sets the interval at which the manager switches to the next screen. 0, the default,
+// turns the rotation off. See also func Rotate
+func (m *ScreenManager) SetRotation(interval time.Duration) {
+	m.mu.Lock()
+	m.rotation = interval
+	m.mu.Unlock()
+	m.notify()
+}
+
+// Rotate returns a ScreenManager rotating the screens, switching to the next one every interval, e.g.
+// for a status display showing the IP address, load and temperature in turn. Use Next and Previous
+// to switch manually, which also restarts the interval, and Pause and Resume to hold the screen shown
+func (l *Device) Rotate(interval time.Duration, screens ...Screen) *ScreenManager {
+	m := l.NewScreenManager(screens...)
+	m.SetRotation(interval)
+	return m
+}
+
+// Pause pauses the rotation, holding the screen shown until Resume is called
+func (m *ScreenManager) Pause() {
+	m.mu.Lock()
+	m.paused = true
+	m.mu.Unlock()
+}
+
+// Resume resumes the rotation, showing the screen shown for a full interval before switching
+func (m *ScreenManager) Resume() {
+	m.mu.Lock()
+	if m.paused {
+		m.paused = false
+		m.shownAt = time.Now()
+	}
+	m.mu.Unlock()
+	m.notify()
+}
+
+// Paused returns true if the rotation is paused
+func (m *ScreenManager) Paused() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.paused
+}
+
+// SetTransition sets how the manager switches between screens, and the duration of the transition.
+// Default is TransitionNone
+func (m *ScreenManager) SetTransition(t Transition, d time.Duration) {
+	m.mu.Lock()
+	m.transition, m.duration = t, d
+	m.mu.Unlock()
+}
+
+// State returns the screen shown, whether the rotation is paused and how much of the rotation
+// interval has passed, e.g. "2 false 4.5s", see type Scene
+func (m *ScreenManager) State() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var passed time.Duration
+	if m.shown >= 0 && m.shown == m.current {
+		passed = time.Since(m.shownAt).Round(time.Millisecond)
+	}
+	return fmt.Sprintf("%d %t %s", m.current, m.paused, passed)
+}
+
+// SetState switches to the screen of a state returned by State, and resumes the rotation where it
+// was. An error is returned if the state is invalid or its screen isn't one of the screens
+func (m *ScreenManager) SetState(state string) error {
+	f := strings.Fields(state)
+	if len(f) != 3 {
+		return fmt.Errorf("Invalid screen manager state %q", state)
+	}
+	index, err := strconv.Atoi(f[0])
+	if err != nil {
+		return err
+	}
+	paused, err := strconv.ParseBool(f[1])
+	if err != nil {
+		return err
+	}
+	passed, err := time.ParseDuration(f[2])
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	if index < 0 || index >= len(m.screens) {
+		m.mu.Unlock()
+		return fmt.Errorf("No screen %d", index)
+	}
+	m.current, m.dir, m.paused = index, 1, paused
+	if index == m.shown {
+		m.shownAt = time.Now().Add(-passed)
+	} else {
+		m.resume = passed
+	}
+	m.mu.Unlock()
+	m.notify()
+	return nil
+}
+
+// Stop stops managing the display, leaving the screen shown as is
+func (m *ScreenManager) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+	<-m.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (m *ScreenManager) Done() <-chan struct{} {
+	return m.done
+}
+
+// step switches delta screens forward or back, wrapping around
+func (m *ScreenManager) step(delta int) {
+	m.mu.Lock()
+	if n := len(m.screens); n > 0 {
+		m.current = ((m.current+delta)%n + n) % n
+		m.dir, m.resume = delta, 0
+	}
+	m.mu.Unlock()
+	m.notify()
+}
+
+// notify wakes the goroutine of the manager, to draw what has changed
+func (m *ScreenManager) notify() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run draws the screens until stopped; when switched, when a redraw is requested and when the
+// refresh or rotation interval has passed
+func (m *ScreenManager) run() {
+	defer close(m.done)
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		m.mu.Lock()
+		now := time.Now()
+		if m.rotation > 0 && !m.paused && m.shown >= 0 && m.current == m.shown && len(m.screens) > 1 &&
+			now.Sub(m.shownAt) >= m.rotation {
+			m.current, m.dir = (m.shown+1)%len(m.screens), 1
+		}
+		switched := m.current != m.shown
+		var s Screen
+		if m.current < len(m.screens) &&
+			(switched || m.redraw || m.refresh > 0 && now.Sub(m.drawnAt) >= m.refresh) {
+			s = m.screens[m.current]
+		}
+		index, dir, transition, duration := m.current, m.dir, m.transition, m.duration
+		m.redraw = false
+		m.mu.Unlock()
+		if s != nil {
+			if switched && m.shown >= 0 && transition == TransitionSlide {
+				m.slide(s, dir, duration)
+			} else {
+				m.l.Update(func(f *Frame) {
+					f.Clear()
+					s.Render(f)
+				})
+			}
+			m.mu.Lock()
+			if switched {
+				m.shownAt, m.resume = time.Now().Add(-m.resume), 0
+			}
+			m.shown, m.drawnAt = index, time.Now()
+			m.mu.Unlock()
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(m.wait())
+		select {
+		case <-m.stop:
+			return
+		case <-m.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// wait returns the time until the screen shown is to be refreshed or rotated
+func (m *ScreenManager) wait() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	wait := time.Hour
+	if m.refresh > 0 {
+		if d := time.Until(m.drawnAt.Add(m.refresh)); d < wait {
+			wait = d
+		}
+	}
+	if m.rotation > 0 && !m.paused && len(m.screens) > 1 {
+		if d := time.Until(m.shownAt.Add(m.rotation)); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+// slide switches to the screen by sliding it in, one column at a time over the duration d, from the
+// right if dir is positive and from the left otherwise
+func (m *ScreenManager) slide(s Screen, dir int, d time.Duration) {
+	var old, next *Frame
+	m.l.Update(func(f *Frame) {
+		old = f.clone()
+		f.Clear()
+		s.Render(f)
+		next = f.clone()
+		f.copyFrom(old)
+	})
+	cols := int(m.l.cols)
+	for k := 1; k <= cols; k++ {
+		k := k // The update may run later, in async mode
+		time.Sleep(d / time.Duration(cols))
+		m.l.Update(func(f *Frame) {
+			for r := range f.cells {
+				if dir > 0 {
+					copy(f.cells[r], old.cells[r][k:])
+					copy(f.cells[r][cols-k:], next.cells[r][:k])
+				} else {
+					copy(f.cells[r], next.cells[r][cols-k:])
+					copy(f.cells[r][k:], old.cells[r][:cols-k])
+				}
+			}
+			if k == cols {
+				f.row, f.col = next.row, next.col
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package st7066u
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SelfTestReport is the result of func SelfTest, naming the data lines found miswired
+type SelfTestReport struct {
+	StuckHigh   []string    // Data lines always read high, e.g. "D5"
+	StuckLow    []string    // Data lines always read low
+	Swapped     [][2]string // Pairs of data lines swapped with each other
+	Unexplained bool        // What was read back doesn't match, but not due to stuck or swapped lines
+}
+
+// OK reports if no wiring problems were found
+func (r SelfTestReport) OK() bool {
+	return len(r.StuckHigh) == 0 && len(r.StuckLow) == 0 && len(r.Swapped) == 0 && !r.Unexplained
+}
+
+// String returns the problems found, e.g. "D5 stuck high, D6 and D7 swapped", or "OK"
+func (r SelfTestReport) String() string {
+	var s []string
+	for _, d := range r.StuckHigh {
+		s = append(s, d+" stuck high")
+	}
+	for _, d := range r.StuckLow {
+		s = append(s, d+" stuck low")
+	}
+	for _, d := range r.Swapped {
+		s = append(s, d[0]+" and "+d[1]+" swapped")
+	}
+	if r.Unexplained {
+		s = append(s, "read back doesn't match")
+	}
+	if len(s) == 0 {
+		return "OK"
+	}
+	return strings.Join(s, ", ")
+}
+
+// SelfTest checks the wiring of the data lines by writing known patterns to the (first) controller
+// and reading them back, see SetRWPin. Data lines stuck high or low show in the data read back.
+// Swapped data lines don't, as the bits are swapped back when read, but show in the address
+// counter moving on from a written address. The content of the display is restored afterwards
+func (l *Device) SelfTest() (SelfTestReport, error) {
+	var rep SelfTestReport
+	err := errNoRW
+	l.doWait(func() {
+		if l.pinRW == nil {
+			return
+		}
+		err = nil
+		ctl := l.ctl
+		l.selectCtl(0)
+		rep = l.selfTest()
+		l.rewrite(l.ddram, l.addr, ctl)
+	})
+	return rep, err
+}
+
+// selfTest runs the self test on the selected controller, leaving the DDRAM and address counter
+// changed
+func (l *Device) selfTest() SelfTestReport {
+	var rep SelfTestReport
+	wires := len(l.pinDs)
+	name := func(w int) string {
+		return fmt.Sprintf("D%d", 8-wires+w)
+	}
+
+	// Walking ones and zeros, written to and read back from DDRAM address 0
+	and, or := uint8(0xff), uint8(0)
+	match := true
+	for i := 0; i < 8; i++ {
+		for _, v := range []uint8{1 << i, ^uint8(1 << i)} {
+			l.write(0x80, cmdInstruction)
+			l.write(v, cmdData)
+			l.write(0x80, cmdInstruction)
+			r := l.read(cmdData)
+			and, or = and&r, or|r
+			match = match && r == v
+		}
+	}
+	for w := 0; w < wires; w++ {
+		switch mask := wireMask(w, wires); {
+		case or&mask == 0:
+			rep.StuckLow = append(rep.StuckLow, name(w))
+		case and&mask == mask:
+			rep.StuckHigh = append(rep.StuckHigh, name(w))
+		}
+	}
+	if len(rep.StuckLow) > 0 || len(rep.StuckHigh) > 0 {
+		return rep
+	}
+	rep.Unexplained = !match
+
+	// The address counter after writing at addresses with one or more bits set
+	var obs [][2]uint8
+	for _, a := range []uint8{0x01, 0x02, 0x04, 0x08, 0x10, 0x20, 0x40, 0x03, 0x07, 0x0f, 0x1f, 0x27} {
+		l.write(0x80|a, cmdInstruction)
+		l.write(0x20, cmdData)
+		obs = append(obs, [2]uint8{a, l.read(cmdInstruction) & 0x7f})
+	}
+	explains := func(a, b int) bool {
+		for _, o := range obs {
+			next := nextAddr(swapWires(o[0], a, b, wires)&0x7f, l.geo.twoLines, true)
+			if swapWires(next, a, b, wires)&0x7f != o[1] {
+				return false
+			}
+		}
+		return true
+	}
+	if explains(0, 0) {
+		return rep
+	}
+	for a := 0; a < wires; a++ {
+		for b := a + 1; b < wires; b++ {
+			if explains(a, b) {
+				rep.Swapped = append(rep.Swapped, [2]string{name(a), name(b)})
+				return rep
+			}
+		}
+	}
+	rep.Unexplained = true
+	return rep
+}
+
+// wireMask returns the bits of a byte carried by the data wire; one in 8-bit mode, and one of each
+// nibble in 4-bit mode
+func wireMask(w, wires int) uint8 {
+	if wires == 4 {
+		return 1<<w | 1<<(w+4)
+	}
+	return 1 << w
+}
+
+// swapWires returns the byte as carried by the data wires with wires a and b swapped
+func swapWires(v uint8, a, b, wires int) uint8 {
+	ma, mb := wireMask(a, wires), wireMask(b, wires)
+	va, vb := v&ma, v&mb
+	v &^= ma | mb
+	if b > a {
+		return v | va<<uint(b-a) | vb>>uint(b-a)
+	}
+	return v | va>>uint(a-b) | vb<<uint(a-b)
+}
@@ -0,0 +1,37 @@
+//go:build !tinygo
+// +build !tinygo
+
+package st7066u
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// CloseOnSignal closes the device with the text when the program receives one of the signals,
+// SIGINT or SIGTERM if none are given, and then exits the program with status 0. See func
+// CloseWithMessage. The returned function stops waiting for the signals, e.g. when the device is
+// closed in another way
+func (l *Device) CloseOnSignal(text string, signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	var once sync.Once
+	signal.Notify(ch, signals...)
+	go func() {
+		select {
+		case <-ch:
+			l.CloseWithMessage(text)
+			os.Exit(0)
+		case <-done:
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		once.Do(func() { close(done) })
+	}
+}
@@ -0,0 +1,413 @@
+package st7066u
+
+import (
+	"strings"
+	"sync"
+)
+
+// Lines of the simulated display, see simPin
+const (
+	lineRS = iota
+	lineRW
+	lineE
+	lineE2
+	lineL
+	lineD0
+)
+
+// Simulator is an in-memory model of the ST7066U controller. It is driven by the Device through
+// the same pin sequences as a real display, and decodes them into the content of the DDRAM and
+// CGRAM. The R/W pin is connected, so what the controller holds can also be read back through the
+// Device, see ReadDDRAM. This allows code using the display to be run and checked without any hardware. Use func
+// NewSimulated to get a Device connected to a new Simulator
+type Simulator struct {
+	mu     sync.Mutex
+	rows   uint8
+	cols   uint8
+	geo    geometry
+	lines  [lineD0 + 8]bool
+	ctls   []*simController
+	active int // The controller last written to, showing the cursor
+}
+
+// simController is the state of one simulated controller
+type simController struct {
+	ctl4      bool  // The controller is in 4-bit mode
+	pending   bool  // The high nibble is latched, waiting for the low nibble
+	high      uint8 // The latched high nibble
+	out       uint8 // The byte being read
+	ddram     [0x80]byte
+	cgram     [64]byte
+	ac        uint8 // Address counter
+	cg        bool  // The address counter points into CGRAM
+	increment bool
+	shiftOn   bool // The display shifts on writes
+	shift     int  // Nr of positions the display is shifted left
+	displayOn bool
+	cursorOn  bool
+	blinkOn   bool
+	twoLines  bool
+}
+
+// simPin is one line into the Simulator
+type simPin struct {
+	s    *Simulator
+	line int
+}
+
+// NewSimulated returns a Device connected to a new Simulator instead of GPIO pins. Arguments are
+// the same as for New, apart from the pins
+func NewSimulated(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8) (*Device, *Simulator, error) {
+	if err := validateSymm(nrOfRows, nrOfCols, charSym); err != nil {
+		return nil, nil, err
+	}
+	return newSimulated(newGeometry(nrOfRows, nrOfCols), charSym, mode)
+}
+
+// NewSimulatedFromProfile returns a Device connected to a new Simulator of a display of a known
+// geometry, see NewFromProfile
+func NewSimulatedFromProfile(profile string, charSym uint8, mode uint8) (*Device, *Simulator, error) {
+	geo, err := profileGeometry(profile, charSym)
+	if err != nil {
+		return nil, nil, err
+	}
+	return newSimulated(geo, charSym, mode)
+}
+
+// NewSimulatedDualController returns a Device connected to a new Simulator of a 40x4 display with
+// two controllers, see NewDualController
+func NewSimulatedDualController(mode uint8) (*Device, *Simulator, error) {
+	return newSimulated(dualGeometry(), DOTS5x8, mode)
+}
+
+// newSimulated returns a Device connected to a new Simulator of a display with the geometry
+func newSimulated(geo geometry, charSym uint8, mode uint8) (*Device, *Simulator, error) {
+	s := &Simulator{
+		rows: uint8(len(geo.offsets)),
+		cols: geo.cols,
+		geo:  geo,
+		ctls: make([]*simController, geo.controllers()),
+	}
+	for i := range s.ctls {
+		c := &simController{increment: true}
+		for a := range c.ddram {
+			c.ddram[a] = 0x20
+		}
+		s.ctls[i] = c
+	}
+	first, nrs := lineD0, 8
+	if mode == BITMODE4 {
+		first, nrs = lineD0+4, 4
+	}
+	if err := validatePinMode(mode, nrs); err != nil {
+		return nil, nil, err
+	}
+	ds := make([]Pin, nrs)
+	for i := range ds {
+		ds[i] = &simPin{s: s, line: first + i}
+	}
+	g := newDevice(geo, charSym, &simPin{s: s, line: lineRS}, &simPin{s: s, line: lineE}, &simPin{s: s, line: lineL}, ds)
+	if len(s.ctls) > 1 {
+		g.pinE2 = &simPin{s: s, line: lineE2}
+	}
+	g.pinRW = &simPin{s: s, line: lineRW}
+	g.backend = "simulator"
+	g.setDefaultMasks()
+	g.start()
+	return g, s, nil
+}
+
+// High implements Pin
+func (p *simPin) High() {
+	p.s.set(p.line, true)
+}
+
+// Low implements Pin
+func (p *simPin) Low() {
+	p.s.set(p.line, false)
+}
+
+// Output implements Pin
+func (p *simPin) Output() {}
+
+// Input implements inPin
+func (p *simPin) Input() {}
+
+// Read implements inPin
+func (p *simPin) Read() pinState {
+	p.s.mu.Lock()
+	defer p.s.mu.Unlock()
+	if p.s.lines[p.line] {
+		return pinHigh
+	}
+	return pinLow
+}
+
+// Backlight reports if the backlight is on
+func (s *Simulator) Backlight() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lines[lineL]
+}
+
+// Cursor returns the position of the cursor, and if it is visible and/or blinking. With two
+// controllers, the cursor of the controller last written to is returned
+func (s *Simulator) Cursor() (row, col uint8, visible, blinking bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.ctls[s.active]
+	if row, col, ok := s.geo.cellAt(s.active, c.ac); ok {
+		return row, col, c.cursorOn, c.blinkOn
+	}
+	found := false
+	for r, o := range s.geo.offsets {
+		if s.geo.ctls[r] == s.active && c.ac >= o && (!found || o > s.geo.offsets[row]) {
+			row, found = uint8(r), true
+		}
+	}
+	col = c.ac - s.geo.offsets[row]
+	return row, col, c.cursorOn, c.blinkOn
+}
+
+// DisplayOn reports if the display is turned on
+func (s *Simulator) DisplayOn() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ctls[0].displayOn
+}
+
+// DDRAM returns a copy of the display data RAM, i.e. the ROM codes of all characters. With two
+// controllers, the DDRAM of the first controller is returned
+func (s *Simulator) DDRAM() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]byte(nil), s.ctls[0].ddram[:]...)
+}
+
+// CGRAM returns a copy of the character generator RAM, i.e. the 8 user-defined characters. With two
+// controllers, the CGRAM of the first controller is returned
+func (s *Simulator) CGRAM() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]byte(nil), s.ctls[0].cgram[:]...)
+}
+
+// Codes returns the ROM codes of the characters visible on each row of the display
+func (s *Simulator) Codes() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	codes := make([][]byte, s.rows)
+	for r := range codes {
+		codes[r] = make([]byte, s.cols)
+		for c := range codes[r] {
+			ctl, addr := s.geo.cellAddr(uint8(r), uint8(c))
+			codes[r][c] = s.ctls[ctl].ddram[s.ctls[ctl].visibleAddr(addr)]
+		}
+	}
+	return codes
+}
+
+// Lines returns the text visible on each row of the display. User-defined characters are returned
+// as the runes '\x00' to '\x07', and codes without a known rune as '?'
+func (s *Simulator) Lines() []string {
+	codes := s.Codes()
+	lines := make([]string, len(codes))
+	for r, row := range codes {
+		var b strings.Builder
+		for _, c := range row {
+			b.WriteRune(st70660bToRune(c))
+		}
+		lines[r] = b.String()
+	}
+	return lines
+}
+
+// String returns the visible text framed as the display, with user-defined characters shown as '#'.
+// A display that is turned off is shown empty
+func (s *Simulator) String() string {
+	on := s.DisplayOn()
+	lines := s.Lines()
+	border := "+" + strings.Repeat("-", int(s.cols)) + "+\n"
+	var b strings.Builder
+	b.WriteString(border)
+	for _, line := range lines {
+		b.WriteByte('|')
+		for _, c := range line {
+			switch {
+			case !on:
+				c = ' '
+			case c < 8:
+				c = '#'
+			}
+			b.WriteRune(c)
+		}
+		b.WriteString("|\n")
+	}
+	b.WriteString(border)
+	return b.String()
+}
+
+// set sets the state of the line, and latches the data lines into the controller on the falling
+// edge of its E line. When reading, the controller drives the data lines from the rising edge
+func (s *Simulator) set(line int, high bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	enable := line == lineE || line == lineE2
+	rising := enable && !s.lines[line] && high
+	latch := enable && s.lines[line] && !high
+	s.lines[line] = high
+	i := 0
+	if line == lineE2 {
+		i = 1
+	}
+	if !(rising || latch) || i >= len(s.ctls) {
+		return
+	}
+	if s.lines[lineRW] {
+		s.read(s.ctls[i], rising)
+		return
+	}
+	if !latch {
+		return
+	}
+	var data uint8
+	for d := 0; d < 8; d++ {
+		if s.lines[lineD0+d] {
+			data |= 1 << d
+		}
+	}
+	c := s.ctls[i]
+	if c.ctl4 {
+		if !c.pending {
+			c.high = data >> 4
+			c.pending = true
+			return
+		}
+		c.pending = false
+		data = c.high<<4 | data>>4
+	}
+	rs := s.lines[lineRS]
+	c.exec(data, rs)
+	switch {
+	case rs || data&0x80 != 0:
+		s.active = i
+	case data&0xfc == 0 && data != 0 && i == 0: // Clear or home
+		s.active = 0
+	}
+}
+
+// read drives the data lines with the byte (or, in 4-bit mode, the nibble) read from the controller
+// on the rising edge of E, and moves the address counter on after a data read on the falling edge
+func (s *Simulator) read(c *simController, rising bool) {
+	rs := s.lines[lineRS]
+	if !rising {
+		if c.ctl4 && !c.pending {
+			c.pending = true
+			return
+		}
+		c.pending = false
+		if rs {
+			c.step(c.increment)
+		}
+		return
+	}
+	if !c.pending {
+		switch {
+		case !rs:
+			c.out = c.ac & 0x7f // Never busy
+		case c.cg:
+			c.out = c.cgram[c.ac&0x3f]
+		default:
+			c.out = c.ddram[c.ac&0x7f]
+		}
+	}
+	out := c.out
+	if c.pending {
+		out <<= 4
+	}
+	for d := 0; d < 8; d++ {
+		s.lines[lineD0+d] = out&(1<<d) != 0
+	}
+}
+
+// exec executes an instruction, or writes data, as the controller does
+func (s *simController) exec(b uint8, rs bool) {
+	if rs {
+		if s.cg {
+			s.cgram[s.ac&0x3f] = b
+		} else {
+			s.ddram[s.ac&0x7f] = b
+			if s.shiftOn {
+				s.shiftDisplay(s.increment)
+			}
+		}
+		s.step(s.increment)
+		return
+	}
+	switch {
+	case b&0x80 != 0:
+		s.ac, s.cg = b&0x7f, false
+	case b&0x40 != 0:
+		s.ac, s.cg = b&0x3f, true
+	case b&0x20 != 0:
+		s.ctl4 = b&0x10 == 0
+		s.twoLines = b&0x08 != 0
+	case b&0x10 != 0:
+		if b&0x08 != 0 {
+			s.shiftDisplay(b&0x04 == 0)
+		} else {
+			s.step(b&0x04 != 0)
+		}
+	case b&0x08 != 0:
+		s.displayOn = b&0x04 != 0
+		s.cursorOn = b&0x02 != 0
+		s.blinkOn = b&0x01 != 0
+	case b&0x04 != 0:
+		s.increment = b&0x02 != 0
+		s.shiftOn = b&0x01 != 0
+	case b&0x02 != 0:
+		s.ac, s.cg, s.shift = 0, false, 0
+	case b&0x01 != 0:
+		for i := range s.ddram {
+			s.ddram[i] = 0x20
+		}
+		s.ac, s.cg, s.shift, s.increment = 0, false, 0, true
+	}
+}
+
+// step moves the address counter one step, wrapping around as the controller does
+func (s *simController) step(increment bool) {
+	if s.cg {
+		if increment {
+			s.ac = (s.ac + 1) & 0x3f
+		} else {
+			s.ac = (s.ac - 1) & 0x3f
+		}
+		return
+	}
+	s.ac = nextAddr(s.ac, s.twoLines, increment) & 0x7f
+}
+
+// shiftDisplay shifts the display one position left or right
+func (s *simController) shiftDisplay(left bool) {
+	if left {
+		s.shift++
+	} else {
+		s.shift--
+	}
+}
+
+// visibleAddr returns the DDRAM address shown in place of addr, taking display shift into account
+func (s *simController) visibleAddr(addr uint8) uint8 {
+	length := 0x50
+	var base uint8
+	if s.twoLines {
+		length = 0x28
+		base = addr & 0x40
+	}
+	pos := (int(addr-base) + s.shift) % length
+	if pos < 0 {
+		pos += length
+	}
+	return base + uint8(pos)
+}
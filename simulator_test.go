@@ -0,0 +1,184 @@
+package st7066u
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// newTestDevice returns a simulated display of the profile, writing without waits, closed when the
+// test ends
+func newTestDevice(t testing.TB, profile string, mode uint8) (*Device, *Simulator) {
+	t.Helper()
+	d, s, err := NewSimulatedFromProfile(profile, DOTS5x8, mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetTiming(Timing{})
+	t.Cleanup(d.Close)
+	return d, s
+}
+
+// checkShadow fails the test if the content, cursor or user-defined characters the device keeps
+// track of differ from what the simulator holds
+func checkShadow(t *testing.T, d *Device, s *Simulator) {
+	t.Helper()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c, ctl := range s.ctls {
+		for _, r := range d.ddramRanges() {
+			for a := r[0]; a < r[1]; a++ {
+				if got, want := d.ddram[c][a], ctl.ddram[a]; got != want {
+					t.Errorf("Controller %d, DDRAM %02x: shadow %02x, simulator %02x", c, a, got, want)
+				}
+			}
+		}
+		if !ctl.cg && d.addr[c] != ctl.ac {
+			t.Errorf("Controller %d: shadow address %02x, simulator %02x", c, d.addr[c], ctl.ac)
+		}
+		for i, slot := range d.slots[:d.nrOfSlots()] {
+			if !slot.used && !slot.fixed {
+				continue
+			}
+			for row, b := range slot.g {
+				if got := ctl.cgram[i*8+row]; got != b&0x1f {
+					t.Errorf("Controller %d, slot %d, row %d: shadow %02x, simulator %02x", c, i, row, b, got)
+				}
+			}
+		}
+	}
+}
+
+// checkLines fails the test if the rows shown by the simulator aren't the lines, padded with spaces
+func checkLines(t *testing.T, s *Simulator, lines ...string) {
+	t.Helper()
+	got := s.Lines()
+	for r, line := range lines {
+		want := line + strings.Repeat(" ", int(s.cols)-len([]rune(line)))
+		if r >= len(got) {
+			t.Errorf("Row %d: missing, the display has %d rows, want %q", r, len(got), want)
+		} else if got[r] != want {
+			t.Errorf("Row %d: got %q, want %q", r, got[r], want)
+		}
+	}
+}
+
+var heart = Glyph{0x00, 0x0a, 0x1f, 0x1f, 0x0e, 0x04, 0x00, 0x00}
+
+func TestSimulated(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		run     func(d *Device)
+		want    []string
+	}{
+		{"print", "1602", func(d *Device) {
+			d.Print("Hello")
+			d.PrintAt(1, 3, "world")
+		}, []string{"Hello", "   world"}},
+		{"print past the row", "1602", func(d *Device) {
+			d.PrintAt(0, 12, "wrapping")
+		}, []string{"            wrap", ""}},
+		{"print wraps on 2004", "2004", func(d *Device) {
+			d.PrintAt(0, 16, "continued")
+		}, []string{"                cont", "", "inued"}},
+		{"clear", "1602", func(d *Device) {
+			d.PrintAt(1, 0, "gone")
+			d.Clear()
+			d.Print("x")
+		}, []string{"x", ""}},
+		{"move left", "1602", func(d *Device) {
+			d.Print("abc")
+			d.MoveLeft(2)
+			d.Print("X")
+		}, []string{"aXc"}},
+		{"insert mode", "1602", func(d *Device) {
+			d.Print("abcd")
+			d.SetCursor(0, 1)
+			d.SetInsertMode(true)
+			d.Print("XY")
+		}, []string{"aXYbcd"}},
+		{"update writes the changes", "2004", func(d *Device) {
+			d.PrintAt(3, 0, "kept")
+			d.Update(func(f *Frame) {
+				f.PrintAt(0, 0, "frame")
+				f.PrintAt(2, 10, "cell")
+			})
+		}, []string{"frame", "", "          cell", "kept"}},
+		{"registered glyphs", "1602", func(d *Device) {
+			d.RegisterGlyph('♥', heart)
+			d.PrintAt(0, 0, "I ♥ Go")
+		}, []string{"I \x00 Go"}},
+		{"icon", "1602", func(d *Device) {
+			d.LoadIcon(heart, 3)
+			d.PrintByte(3)
+		}, []string{"\x03"}},
+		{"thermostat", "1602", func(d *Device) {
+			for _, temp := range []float64{18.5, 19.2, 21.3} {
+				state := "Idle"
+				if temp < 21 {
+					state = "Heat"
+				}
+				d.PrintAt(0, 0, fmt.Sprintf("Temp %5.1f C    ", temp))
+				d.PrintAt(1, 0, fmt.Sprintf("Set  %5.1f %s ", 21.0, state))
+			}
+		}, []string{"Temp  21.3 C", "Set   21.0 Idle"}},
+		{"network monitor", "2004", func(d *Device) {
+			for _, page := range [][2]string{{"eth0", "10.0.0.2"}, {"wlan0", "192.168.1.20"}} {
+				d.Clear()
+				d.PrintAt(0, 0, fmt.Sprintf("%-*s%s", 20-len(page[1]), page[0], page[1]))
+			}
+		}, []string{"wlan0   192.168.1.20", ""}},
+		{"jukebox", "1602", func(d *Device) {
+			title := []rune("1. Heroes - David Bowie   ")
+			for step := 0; step < 5; step++ {
+				line := make([]rune, 16)
+				for i := range line {
+					line[i] = title[(step+i)%len(title)]
+				}
+				d.PrintAt(0, 0, string(line))
+				d.PrintAt(1, 0, fmt.Sprintf("00:%02d / 06:07", step))
+			}
+		}, []string{"eroes - David Bo", "00:04 / 06:07"}},
+	}
+	for _, tt := range tests {
+		for _, mode := range []uint8{BITMODE4, BITMODE8} {
+			tt, mode := tt, mode
+			t.Run(fmt.Sprintf("%s/%d-bit", tt.name, 4+4*mode), func(t *testing.T) {
+				d, s := newTestDevice(t, tt.profile, mode)
+				tt.run(d)
+				checkLines(t, s, tt.want...)
+				checkShadow(t, d, s)
+			})
+		}
+	}
+}
+
+func TestSimulatedAsync(t *testing.T) {
+	d, s := newTestDevice(t, "1602", BITMODE4)
+	d.SetAsync(16)
+	for i := 0; i < 10; i++ {
+		d.PrintAt(0, 0, fmt.Sprintf("Count %d", i))
+	}
+	d.Flush()
+	checkLines(t, s, "Count 9")
+	checkShadow(t, d, s)
+}
+
+func TestSimulatedBacklight(t *testing.T) {
+	d, s := newTestDevice(t, "1602", BITMODE4)
+	d.LedOn(true)
+	if !s.Backlight() {
+		t.Error("Backlight off after LedOn(true)")
+	}
+	d.SetBacklightActiveLow(true)
+	if s.Backlight() {
+		t.Error("L pin high with an active low backlight turned on")
+	}
+	d.LedOn(false)
+	if !s.Backlight() {
+		t.Error("L pin low with an active low backlight turned off")
+	}
+}
@@ -0,0 +1,130 @@
+//go:build go1.21 && !st7066u_small
+// +build go1.21,!st7066u_small
+
+package st7066u
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// SlogOptions are the options used by func SlogHandler
+type SlogOptions struct {
+	Level      slog.Leveler // Minimum level of records shown, default is slog.LevelInfo
+	BlinkLevel slog.Leveler // Records at or above this level blink the backlight, nil disables blinking
+}
+
+// SlogHandler is a slog.Handler showing log records on the display, one compact line per record
+// ("E message key=value"), truncated to the width of the display and scrolling like a Terminal.
+// Use func SlogHandler to get a new struct
+type SlogHandler struct {
+	term  *Terminal
+	opts  SlogOptions
+	attrs string
+	group string
+	blink *sync.Mutex
+}
+
+// SlogHandler returns a new slog.Handler writing to the device, e.g.
+//
+//	slog.SetDefault(slog.New(lcd.SlogHandler(nil)))
+func (l *Device) SlogHandler(opts *SlogOptions) *SlogHandler {
+	h := &SlogHandler{
+		term:  l.Terminal(),
+		blink: &sync.Mutex{},
+	}
+	if opts != nil {
+		h.opts = *opts
+	}
+	if h.opts.Level == nil {
+		h.opts.Level = slog.LevelInfo
+	}
+	return h
+}
+
+// Enabled implements slog.Handler
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.opts.Level.Level()
+}
+
+// Handle implements slog.Handler
+func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(levelLetter(r.Level))
+	b.WriteByte(' ')
+	b.WriteString(r.Message)
+	b.WriteString(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		writeAttr(&b, h.group, a)
+		return true
+	})
+	line := b.String()
+	cols := int(h.term.dev.cols)
+	if utf8.RuneCountInString(line) > cols {
+		line = string([]rune(line)[:cols])
+	}
+	if _, err := h.term.Write([]byte(line + "\n")); err != nil {
+		return err
+	}
+	if h.opts.BlinkLevel != nil && r.Level >= h.opts.BlinkLevel.Level() {
+		go h.blinkBacklight()
+	}
+	return nil
+}
+
+// WithAttrs implements slog.Handler
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	var b strings.Builder
+	for _, a := range attrs {
+		writeAttr(&b, h.group, a)
+	}
+	h2 := *h
+	h2.attrs += b.String()
+	return &h2
+}
+
+// WithGroup implements slog.Handler
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group += name + "."
+	return &h2
+}
+
+// blinkBacklight blinks the backlight three times, leaving it as it was. Blinks triggered while
+// already blinking are skipped
+func (h *SlogHandler) blinkBacklight() {
+	if !h.blink.TryLock() {
+		return
+	}
+	defer h.blink.Unlock()
+	h.term.dev.blinkBacklight(3, time.Millisecond*300, nil)
+}
+
+// levelLetter returns the one letter abbreviation of the level
+func levelLetter(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return "E"
+	case level >= slog.LevelWarn:
+		return "W"
+	case level >= slog.LevelInfo:
+		return "I"
+	}
+	return "D"
+}
+
+// writeAttr writes the attribute as " key=value" to b
+func writeAttr(b *strings.Builder, group string, a slog.Attr) {
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	fmt.Fprintf(b, " %s%s=%v", group, a.Key, a.Value)
+}
@@ -0,0 +1,68 @@
+package st7066u
+
+import (
+	"sync"
+	"time"
+)
+
+// Splash is a screen shown while the display starts, e.g. the brand and version of an appliance,
+// see func SetSplash
+type Splash struct {
+	Lines    []string      // Text of the splash, one line per row from the top, centered
+	Icons    []Glyph       // Glyphs loaded into the user-defined characters 0 - 7 while shown, printed as "\x00" to "\x07"
+	Duration time.Duration // How long the splash is shown before the display is cleared
+}
+
+// splash is the splash shown by the constructors, if any
+var (
+	splashMu sync.Mutex
+	splash   Splash
+)
+
+// SetSplash sets the splash shown by the constructors (New, NewFromProfile etc.) when the display has
+// been initialized, for the duration of the splash, before clearing the display. The constructors
+// block while the splash is shown. Icons are loaded with LoadIcon, and unloaded when the splash is
+// cleared. A Splash with no lines, or a duration of 0, turns the splash off, which is the default
+func SetSplash(s Splash) {
+	splashMu.Lock()
+	defer splashMu.Unlock()
+	splash = Splash{
+		Lines:    append([]string(nil), s.Lines...),
+		Icons:    append([]Glyph(nil), s.Icons...),
+		Duration: s.Duration,
+	}
+}
+
+// start initializes the display and shows the splash, if any, as the last step of the constructors
+func (l *Device) start() {
+	l.init()
+	splashMu.Lock()
+	s := splash
+	splashMu.Unlock()
+	if len(s.Lines) == 0 || s.Duration <= 0 {
+		return
+	}
+	for i, g := range s.Icons {
+		if err := l.LoadIcon(g, uint8(i)); err != nil {
+			break
+		}
+	}
+	l.Update(func(f *Frame) {
+		for r, line := range s.Lines {
+			if r >= int(f.rows) {
+				break
+			}
+			n := len([]rune(line))
+			col := 0
+			if n < int(f.cols) {
+				col = (int(f.cols) - n) / 2
+			}
+			f.PrintAt(uint8(r), uint8(col), line)
+		}
+	})
+	time.Sleep(s.Duration)
+	l.Clear()
+	for i := range s.Icons {
+		l.UnloadIcon(uint8(i))
+	}
+}
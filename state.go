@@ -0,0 +1,67 @@
+package st7066u
+
+// IsDisplayOn returns true if the display is turned on, see TurnOn. The display may still be off
+// while idle, see Idle
+func (l *Device) IsDisplayOn() bool {
+	return l.displayBit(1 << 2)
+}
+
+// IsCursorVisible returns true if the cursor is shown, see CursorOn
+func (l *Device) IsCursorVisible() bool {
+	return l.displayBit(1 << 1)
+}
+
+// IsBlinking returns true if the cursor blinks, see CursorBlink
+func (l *Device) IsBlinking() bool {
+	return l.displayBit(1 << 0)
+}
+
+// IsBacklightOn returns true if the backlight is turned on, see LedOn. The backlight may still be off
+// while idle, see Idle
+func (l *Device) IsBacklightOn() bool {
+	var on bool
+	l.doWait(func() { on = l.ledOn })
+	return on
+}
+
+// Rows returns the nr of rows of the display
+func (l *Device) Rows() uint8 {
+	return l.rows
+}
+
+// Cols returns the nr of columns of the display
+func (l *Device) Cols() uint8 {
+	return l.cols
+}
+
+// EntryMode returns the entry mode set instruction last written, 0b000001DS with D set if the cursor
+// moves to the right and S if the display shifts
+func (l *Device) EntryMode() uint8 {
+	var ins uint8
+	l.doWait(func() { ins = l.entryMode })
+	return ins
+}
+
+// DisplayControl returns the display on/off control instruction last written, 0b00001DCB with D set
+// if the display is on, C if the cursor is shown and B if it blinks. The display may still be off
+// while idle, see Idle
+func (l *Device) DisplayControl() uint8 {
+	var ins uint8
+	l.doWait(func() { ins = l.displayCtl })
+	return ins
+}
+
+// FunctionSet returns the function set instruction last written, 0b001DNF00 with D set for the 8-bit
+// mode, N for two lines and F for 5x11 dot characters. The lowest bits are the font table of the
+// WS0010, see SetROM
+func (l *Device) FunctionSet() uint8 {
+	var ins uint8
+	l.doWait(func() { ins = l.functionSet })
+	return ins
+}
+
+// displayBit returns true if the bit of the display control mask is set, once the operations queued
+// before are done
+func (l *Device) displayBit(mask uint8) bool {
+	return l.DisplayControl()&mask != 0
+}
@@ -0,0 +1,49 @@
+package st7066u
+
+import "time"
+
+// Stats are counters of what has been written to a Device since it was opened, see func Stats
+type Stats struct {
+	BytesWritten    uint64        // Nr of bytes written, instructions and data
+	Instructions    uint64        // Nr of instructions written
+	Clears          uint64        // Nr of times the display has been cleared
+	Updates         uint64        // Nr of updates (see func Update) that wrote changes to the display
+	BusErrors       uint64        // Nr of writes and reads of the display that failed, e.g. on the I2C bus
+	AvgWriteLatency time.Duration // Average time it takes to write a byte, including the waits for the controller
+}
+
+// WriteHooks are functions called around every byte written to the display, see func
+// SetWriteHooks. Either may be nil
+type WriteHooks struct {
+	Before func(b uint8, data bool)                     // Called before the byte is written, data is false for an instruction
+	After  func(b uint8, data bool, took time.Duration) // Called after the byte is written, with the time it took
+}
+
+// Stats returns counters of what has been written to the display since it was opened, e.g. for long
+// running programs to monitor the throughput. To publish them with expvar, use e.g.
+//
+//	expvar.Publish("lcd", expvar.Func(func() interface{} { return lcd.Stats() }))
+func (l *Device) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := Stats{
+		BytesWritten: l.written,
+		Instructions: l.instructions,
+		Clears:       l.clears,
+		Updates:      l.updates,
+		BusErrors:    l.busErrors,
+	}
+	if l.written > 0 {
+		s.AvgWriteLatency = l.writeTime / time.Duration(l.written)
+	}
+	return s
+}
+
+// SetWriteHooks sets functions called around every byte written to the display, e.g. to collect
+// metrics of a program. The hooks are called while holding the display, so they must not call any
+// methods of the device, and should return quickly. An empty WriteHooks removes the hooks
+func (l *Device) SetWriteHooks(h WriteHooks) {
+	l.do(func() {
+		l.hooks = h
+	})
+}
@@ -0,0 +1,174 @@
+package st7066u
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// Keys of the values persisted by the device, see func SetStore
+const (
+	keyBrightness = "brightness"
+	keyWritten    = "written"
+)
+
+// ErrNotStored is returned by Store.Load when no value is stored for the key
+var ErrNotStored = errors.New("No value stored for the key")
+
+// Store persists small values by key, e.g. the settings and counters of a Device (see func
+// SetStore). Implement it to persist to e.g. NVRAM or a database, or use MemoryStore or FileStore
+type Store interface {
+	Load(key string) ([]byte, error) // Returns ErrNotStored if there is no value for the key
+	Save(key string, value []byte) error
+}
+
+// MemoryStore is a Store keeping the values in memory only, i.e. nothing survives a restart. Use
+// func NewMemoryStore to get a new struct
+type MemoryStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+// NewMemoryStore returns a new, empty, MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{values: make(map[string][]byte)}
+}
+
+// Load implements Store
+func (m *MemoryStore) Load(key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.values[key]
+	if !ok {
+		return nil, ErrNotStored
+	}
+	return append([]byte(nil), v...), nil
+}
+
+// Save implements Store
+func (m *MemoryStore) Save(key string, value []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = append([]byte(nil), value...)
+	return nil
+}
+
+// FileStore is a Store keeping each value in a file, named as the key, in a directory. Point it at
+// e.g. a tmpfs mount on systems with a read-only root file system. Use func NewFileStore to get a
+// new struct
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore keeping the values in the directory, which is created if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// Load implements Store
+func (s *FileStore) Load(key string) ([]byte, error) {
+	v, err := ioutil.ReadFile(filepath.Join(s.dir, key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotStored
+	}
+	return v, err
+}
+
+// Save implements Store. The value is written to a temporary file that is then renamed, so that a
+// power loss never leaves a partly written value
+func (s *FileStore) Save(key string, value []byte) error {
+	f, err := ioutil.TempFile(s.dir, "."+key)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(value)
+	if err2 := f.Close(); err == nil {
+		err = err2
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), filepath.Join(s.dir, key))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// SetStore sets the Store used to persist the brightness of the backlight, the nr of bytes written
+// to the display (see func Written) and the timing found by CalibrateTiming, and restores them from
+// it. The brightness is only restored if it can be set, see SetBrightness. The nr of bytes written
+// is saved when the device is closed. nil stops persisting
+func (l *Device) SetStore(s Store) error {
+	level, written := -1.0, uint64(0)
+	var timing *Timing
+	if s != nil {
+		v, err := loadValue(s, keyBrightness)
+		if err == nil && v != "" {
+			level, err = strconv.ParseFloat(v, 64)
+		}
+		if err != nil {
+			return err
+		}
+		v, err = loadValue(s, keyWritten)
+		if err == nil && v != "" {
+			written, err = strconv.ParseUint(v, 10, 64)
+		}
+		if err != nil {
+			return err
+		}
+		v, err = loadValue(s, keyTiming)
+		if err == nil && v != "" {
+			var t Timing
+			t, err = ParseTiming(v)
+			timing = &t
+		}
+		if err != nil {
+			return err
+		}
+	}
+	l.mu.Lock()
+	l.store = s
+	l.writtenBefore = written
+	l.mu.Unlock()
+	if timing != nil {
+		l.SetTiming(*timing)
+	}
+	if level >= 0 && l.hasPwmBacklight() {
+		return l.SetBrightness(level)
+	}
+	return nil
+}
+
+// Written returns the nr of bytes written to the display, including those written by earlier runs
+// of the program when a Store is used, see SetStore
+func (l *Device) Written() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.writtenBefore + l.written
+}
+
+// loadValue returns the value stored for the key, or "" if there is none
+func loadValue(s Store, key string) (string, error) {
+	v, err := s.Load(key)
+	if err == ErrNotStored {
+		return "", nil
+	}
+	return string(v), err
+}
+
+// saveValue saves the value for the key, if a Store is set
+func (l *Device) saveValue(key, value string) error {
+	l.mu.Lock()
+	s := l.store
+	l.mu.Unlock()
+	if s == nil {
+		return nil
+	}
+	return s.Save(key, []byte(value))
+}
@@ -0,0 +1,98 @@
+package st7066u
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var stressDuration = flag.Duration("stress", time.Second*3, "How long TestStress runs")
+
+// TestStress runs many goroutines against one simulated display at the same time: fields updated by
+// their own goroutines, pages rotating, alerts blinking the backlight and a log written to a
+// Terminal, while the async mode, the watchdog and reinitialization are switched on and off. In the
+// end the content the device keeps track of is compared to what the simulated controller shows,
+// which only match if no writes were interleaved. Run it with the race detector, e.g.
+//
+//	go test -race -run TestStress -stress 10s
+func TestStress(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipped in short mode")
+	}
+	d, s := newTestDevice(t, "1602", BITMODE8)
+	d.SetMirror(func(row uint8, text string) {})
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	run := func(interval time.Duration, fn func(i int)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				case <-time.After(time.Duration(rand.Int63n(int64(interval)))):
+				}
+				fn(i)
+			}
+		}()
+	}
+
+	// Fields, each with its own goroutine
+	for n := 0; n < 8; n++ {
+		row, col := uint8(n%2), uint8((n/2)*4%16)
+		run(time.Millisecond*20, func(i int) {
+			d.Update(func(f *Frame) {
+				f.PrintAt(row, col, fmt.Sprintf("%4d", i%10000))
+			})
+		})
+	}
+	// Pages rotating
+	run(time.Millisecond*500, func(i int) {
+		d.ShowDiagnostics(time.Millisecond * 50)
+	})
+	// Alerts
+	run(time.Millisecond*200, func(i int) {
+		d.LedOn(i%2 == 0)
+		d.CursorBlink(i%3 == 0)
+	})
+	// A log
+	term := d.Terminal()
+	run(time.Millisecond*100, func(i int) {
+		fmt.Fprintf(term, "log line %d\n", i)
+	})
+	// Subsystems switched on and off
+	run(time.Millisecond*300, func(i int) {
+		if i%2 == 0 {
+			d.SetAsync(1 + rand.Intn(16))
+		} else {
+			d.SetAsync(0)
+		}
+	})
+	run(time.Millisecond*400, func(i int) {
+		d.SetWatchdog(time.Duration(i%2) * time.Millisecond * 30)
+	})
+	run(time.Second, func(i int) {
+		d.Reinit(true)
+	})
+
+	time.Sleep(*stressDuration)
+	close(stop)
+	wg.Wait()
+	d.SetWatchdog(0)
+	d.SetAsync(0)
+
+	rows := make([]string, 2)
+	d.SetMirror(func(row uint8, text string) { rows[row] = text })
+	for r, line := range s.Lines() {
+		if line = strings.TrimRight(line, " "); line != rows[r] {
+			t.Errorf("Row %d: device has %q, display shows %q", r, rows[r], line)
+		}
+	}
+	checkShadow(t, d, s)
+}
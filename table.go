@@ -0,0 +1,30 @@
+package st7066u
+
+// RenderTable shows the rows of cells as aligned columns, one row of the table per row of the
+// display from the top, replacing what was shown, e.g. key/value pairs or tabular data on a 20x4
+// display. Column i is widths[i] cells wide, from the left edge of the display, and its cells are
+// left-aligned, padded with spaces and truncated to the width, so leave a cell of the width for the
+// gap to the next column. Cells without a width, columns beyond the last column of the display,
+// and rows beyond the last row, are dropped. Only the cells that changed are written
+func (l *Device) RenderTable(rows [][]string, widths []uint8) {
+	l.Update(func(f *Frame) { f.RenderTable(rows, widths) })
+}
+
+// RenderTable shows the rows of cells as aligned columns in the frame, as func RenderTable of the
+// Device does, e.g. in the Render method of a Screen
+func (f *Frame) RenderTable(rows [][]string, widths []uint8) {
+	f.Clear()
+	for r, cells := range rows {
+		if r >= int(f.rows) {
+			break
+		}
+		col := 0
+		for i, text := range cells {
+			if i >= len(widths) || col >= int(f.cols) {
+				break
+			}
+			f.printField(uint8(r), uint8(col), widths[i], text, false)
+			col += int(widths[i])
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package st7066u
+
+import "errors"
+
+// Tee writes every byte written to the display to the targets as well, e.g. a second LCD showing the
+// same content, or a simulated display (see func NewSimulated) recorded with RenderImage. The
+// targets must have the same nr of rows, cols, controllers and dot matrix as the display, and are
+// first written with what the display shows, its user-defined characters and backlight. The targets
+// keep track of the content as if written to directly, so their Screenshot, mirror function etc
+// work, but should not be written to by other means, and must not tee back to the display. Each byte
+// is written to the targets in turn after the display, adding their write time to every write, except
+// the instructions of the extended instruction sets of the controller variant (see SetVariant) and
+// the writes of BlinkRegion and CalibrateTiming. Tee without targets stops writing to the targets
+func (l *Device) Tee(targets ...*Device) error {
+	for _, t := range targets {
+		if t == l {
+			return errors.New("A display can't be its own tee target")
+		}
+		if t.rows != l.rows || t.cols != l.cols || t.sym != l.sym ||
+			t.geo.controllers() != l.geo.controllers() {
+			return errors.New("The tee targets must be of the same size as the display")
+		}
+	}
+	l.do(func() {
+		l.tees = append([]*Device(nil), targets...)
+		for _, t := range l.tees {
+			t.mu.Lock()
+			t.ddram, t.addr, t.ctl = l.ddram, l.addr, l.ctl
+			t.entryMode, t.displayCtl = l.entryMode, l.displayCtl
+			t.slots = l.slots
+			t.ledOn, t.brightness = l.backlightOn(), l.brightness
+			t.feedCG, t.feedExt = false, false
+			t.refresh()
+			t.unlockSync()
+		}
+	})
+	return nil
+}
+
+// feedTees writes the byte written to the display, to the controller ctl (-1 for all), to the tee
+// targets, unless held
+func (l *Device) feedTees(data uint8, cmd uint8, ctl int) {
+	if l.teeHeld {
+		return
+	}
+	for _, t := range l.tees {
+		t.mu.Lock()
+		t.feed(data, cmd, ctl, l.variant)
+		if t.feedCG {
+			t.slots = l.slots
+		}
+		t.unlockSync()
+	}
+}
+
+// feed writes the byte written to another display, of the controller variant v, to the controller
+// ctl (-1 for all), keeping track of the content and cursor as the controller does. The function set
+// instructions are dropped, as the display keeps its own interface mode, as are the instructions
+// specific to a variant of the controller, and all bytes written while the other display has an
+// extended instruction set selected, e.g. the contrast of an ST7036, which would otherwise be taken
+// for set CGRAM address instructions
+func (l *Device) feed(data uint8, cmd uint8, ctl int, v Variant) {
+	if cmd == cmdInstruction && data&0xe0 == 0x20 {
+		switch v {
+		case ST7036:
+			l.feedExt = data&0b11 != 0 // Instruction table 1 or 2
+		case US2066:
+			l.feedExt = data&0b10 != 0 // RE set
+		}
+		return
+	}
+	if l.feedExt {
+		return
+	}
+	ctls := []int{l.ctl}
+	switch {
+	case l.pinE2 == nil:
+	case ctl < 0:
+		l.all = true
+		ctls = []int{0, 1}
+	default:
+		l.ctl = ctl
+		ctls[0] = ctl
+	}
+	defer func() { l.all = false }()
+	if cmd == cmdData {
+		l.write(data, cmd)
+		if l.feedCG {
+			return
+		}
+		increment := l.entryMode&0b10 != 0
+		for _, c := range ctls {
+			l.ddram[c][l.addr[c]&0x7f] = data
+			l.addr[c] = nextAddr(l.addr[c], l.geo.twoLines, increment)
+		}
+		return
+	}
+	switch {
+	case data&0x80 != 0:
+		l.feedCG = false
+		for _, c := range ctls {
+			l.addr[c] = data & 0x7f
+		}
+	case data&0x40 != 0:
+		l.feedCG = true
+	case data&0x10 != 0:
+		if data&0b11 != 0 {
+			return
+		}
+		if data&0b1000 == 0 {
+			for _, c := range ctls {
+				l.addr[c] = nextAddr(l.addr[c], l.geo.twoLines, data&0b100 != 0)
+			}
+		}
+	case data&0x08 != 0:
+		l.displayCtl = data
+	case data&0x04 != 0:
+		l.entryMode = data
+	case data&0x03 != 0:
+		l.feedCG = false
+		for _, c := range ctls {
+			if data&0x01 != 0 {
+				for a := range l.ddram[c] {
+					l.ddram[c][a] = 0x20
+				}
+			}
+			l.addr[c] = 0
+		}
+		l.write(data, cmd)
+		l.waitExec()
+		return
+	}
+	l.write(data, cmd)
+}
@@ -0,0 +1,37 @@
+package st7066u
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTee(t *testing.T) {
+	d, _ := newTestDevice(t, "1602", BITMODE4)
+	target, s := newTestDevice(t, "1602", BITMODE8)
+	if err := d.Tee(target); err != nil {
+		t.Fatal(err)
+	}
+	d.PrintAt(0, 0, "teed")
+	checkLines(t, s, "teed")
+	checkShadow(t, target, s)
+
+	// The blanking of a blinking region isn't teed
+	e := d.BlinkRegion(0, 0, 4, time.Millisecond*5)
+	for i := 0; i < 10; i++ {
+		time.Sleep(time.Millisecond * 2)
+		checkLines(t, s, "teed")
+	}
+	e.Stop()
+
+	// Extended instructions, e.g. the contrast of an ST7036, are dropped
+	d.doWait(func() {
+		for _, ins := range []uint8{d.functionSet | 1, 0x14, 0x78, 0x5e, 0x6d, d.functionSet} {
+			target.mu.Lock()
+			target.feed(ins, cmdInstruction, -1, ST7036)
+			target.unlockSync()
+		}
+	})
+	d.PrintAt(1, 0, "after")
+	checkLines(t, s, "teed", "after")
+	checkShadow(t, target, s)
+}
@@ -0,0 +1,60 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package st7066u
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+)
+
+// RenderTemplate executes the template with the data, e.g. a struct, and shows the output, one line
+// per row from the top, replacing what was shown. Lines are truncated to the width of the display,
+// and lines beyond the last row are dropped, so layouts can be defined declaratively, e.g.
+//
+//	template.Must(template.New("status").Parse("IP {{.IP}}\nLoad {{printf \"%.2f\" .Load}}"))
+//
+// If executing the template fails, the error is returned and the display is left as is. Only the
+// cells that changed are written
+func (l *Device) RenderTemplate(tmpl *template.Template, data interface{}) error {
+	lines, err := templateLines(tmpl, data)
+	if err != nil {
+		return err
+	}
+	l.Update(func(f *Frame) { f.printLines(lines) })
+	return nil
+}
+
+// RenderTemplate executes the template with the data and shows the output in the frame, as func
+// RenderTemplate of the Device does, e.g. in the Render method of a Screen
+func (f *Frame) RenderTemplate(tmpl *template.Template, data interface{}) error {
+	lines, err := templateLines(tmpl, data)
+	if err != nil {
+		return err
+	}
+	f.printLines(lines)
+	return nil
+}
+
+// templateLines executes the template with the data, and returns the lines of the output
+func templateLines(tmpl *template.Template, data interface{}) ([]string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(b.String(), "\r", ""), "\n"), "\n"), nil
+}
+
+// printLines clears the frame and prints the lines, one per row from the top. Text beyond the last
+// column, and lines beyond the last row, are dropped
+func (f *Frame) printLines(lines []string) {
+	f.Clear()
+	for r, line := range lines {
+		if r >= int(f.rows) {
+			break
+		}
+		f.PrintAt(uint8(r), 0, line)
+	}
+	f.SetCursor(0, 0)
+}
@@ -0,0 +1,142 @@
+package st7066u
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const defaultScrollback = 100
+
+// Terminal is a scrolling text sink for the LCD display. Text written to it is appended at the bottom
+// row, and the content is shifted up as new lines arrive, e.g. to show the tail of a log:
+//
+//	log.SetOutput(lcd.Terminal())
+//
+// Use func Terminal to get a new struct
+type Terminal struct {
+	dev        *Device
+	mu         sync.Mutex
+	lines      []string // Completed lines, oldest first
+	cur        []rune   // The line currently being written
+	scrollback int
+	raw        bool // ANSI escape sequences are not stripped
+	ansi       ansiFilter
+}
+
+// Terminal returns a new Terminal writing to the device. Lines longer than the width of the display
+// are wrapped
+func (l *Device) Terminal() *Terminal {
+	return &Terminal{
+		dev:        l,
+		scrollback: defaultScrollback,
+	}
+}
+
+// Write implements io.Writer. Newlines end the current line, all other text is appended to it
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, c := range string(p) {
+		if !t.raw && !t.ansi.keep(c) {
+			continue
+		}
+		switch c {
+		case '\n':
+			t.newLine()
+		case '\r':
+		default:
+			if len(t.cur) == int(t.dev.cols) {
+				t.newLine()
+			}
+			if c == '\t' {
+				c = ' '
+			}
+			t.cur = append(t.cur, c)
+		}
+	}
+	t.render()
+	return len(p), nil
+}
+
+// SetStripANSI sets if ANSI escape sequences (colors, cursor movement etc.) are stripped from the
+// text written to the terminal, as is common when piping the output of command line tools. Default
+// is true
+func (t *Terminal) SetStripANSI(on bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.raw = !on
+	t.ansi = ansiFilter{}
+}
+
+// Clear clears the terminal, including the scrollback
+func (t *Terminal) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lines = nil
+	t.cur = nil
+	t.render()
+}
+
+// Lines returns the scrollback, oldest line first. The line currently being written is not included
+func (t *Terminal) Lines() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lines := make([]string, len(t.lines))
+	copy(lines, t.lines)
+	return lines
+}
+
+// SetScrollback sets the max nr of completed lines kept by the terminal. Default is 100. In bounded
+// memory mode (see func SetBoundedMemory) only the lines shown on the display are kept
+func (t *Terminal) SetScrollback(n int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n < int(t.dev.rows) {
+		n = int(t.dev.rows)
+	}
+	t.scrollback = n
+	t.trim()
+}
+
+// newLine moves the current line to the scrollback
+func (t *Terminal) newLine() {
+	t.lines = append(t.lines, string(t.cur))
+	t.cur = t.cur[:0]
+	t.trim()
+}
+
+// trim drops the oldest lines exceeding the scrollback, or the rows of the display in bounded
+// memory mode
+func (t *Terminal) trim() {
+	max := t.scrollback
+	if t.dev.boundedMemory() {
+		max = int(t.dev.rows)
+	}
+	if len(t.lines) > max {
+		t.lines = append(t.lines[:0], t.lines[len(t.lines)-max:]...)
+	}
+}
+
+// render shows the last lines on the display. The line being written is shown on the bottom row as
+// soon as it has any content
+func (t *Terminal) render() {
+	visible := t.lines
+	if len(t.cur) > 0 {
+		visible = append(visible[:len(visible):len(visible)], string(t.cur))
+	}
+	rows := int(t.dev.rows)
+	if len(visible) > rows {
+		visible = visible[len(visible)-rows:]
+	}
+	for r := 0; r < rows; r++ {
+		line := ""
+		if r < len(visible) {
+			line = visible[r]
+		}
+		if n := utf8.RuneCountInString(line); n < int(t.dev.cols) {
+			line += strings.Repeat(" ", int(t.dev.cols)-n)
+		}
+		t.dev.PrintAt(uint8(r), 0, line)
+	}
+}
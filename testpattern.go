@@ -0,0 +1,71 @@
+package st7066u
+
+import "time"
+
+// testPatternStep is how long each step of func TestPattern is shown
+const testPatternStep = time.Millisecond * 500
+
+// testGlyphs are the user-defined characters cycled through by func TestPattern; checkerboards,
+// stripes and halves, showing dots that don't light or don't go out
+var testGlyphs = [8]Glyph{
+	{0x15, 0x0a, 0x15, 0x0a, 0x15, 0x0a, 0x15, 0x0a},
+	{0x0a, 0x15, 0x0a, 0x15, 0x0a, 0x15, 0x0a, 0x15},
+	{0x1f, 0x00, 0x1f, 0x00, 0x1f, 0x00, 0x1f, 0x00},
+	{0x00, 0x1f, 0x00, 0x1f, 0x00, 0x1f, 0x00, 0x1f},
+	{0x15, 0x15, 0x15, 0x15, 0x15, 0x15, 0x15, 0x15},
+	{0x0a, 0x0a, 0x0a, 0x0a, 0x0a, 0x0a, 0x0a, 0x0a},
+	{0x1f, 0x1f, 0x1f, 0x1f, 0x00, 0x00, 0x00, 0x00},
+	{0x1c, 0x1c, 0x1c, 0x1c, 0x1c, 0x1c, 0x1c, 0x1c},
+}
+
+// TestPattern shows a test pattern, e.g. to check the wiring of a new display at a glance: every
+// cell with all dots lit (the 0xff character), then the user-defined characters loaded with
+// checkerboards and stripes, cycled through every cell, and last the backlight turned off and on
+// twice. The display is turned on meanwhile. It returns when done, after about 6 seconds, holding
+// the display meanwhile, and leaves the content, the user-defined characters, the display and the
+// backlight as they were
+func (l *Device) TestPattern() {
+	l.doWait(func() {
+		ddram, addr, ctl, slots := l.ddram, l.addr, l.ctl, l.slots
+		displayCtl, ledOn := l.displayCtl, l.ledOn
+		if l.displayCtl&0b100 == 0 {
+			l.displayCtl |= 0b100
+			l.writeDisplay()
+		}
+		l.fillPattern(func(row, col uint8) byte { return 0xff })
+		time.Sleep(testPatternStep * 2)
+		n := l.nrOfSlots()
+		for i := 0; i < n; i++ {
+			l.slots[i] = glyphSlot{g: testGlyphs[i], fixed: true}
+			l.writeGlyph(i)
+		}
+		for step := 0; step < n; step++ {
+			l.fillPattern(func(row, col uint8) byte {
+				return l.slotCode((int(row)*int(l.cols) + int(col) + step) % n)
+			})
+			time.Sleep(testPatternStep)
+		}
+		for i := 0; i < 4; i++ {
+			l.ledOn = i%2 != 0
+			l.applyBacklight()
+			time.Sleep(testPatternStep / 2)
+		}
+		l.slots, l.displayCtl, l.ledOn = slots, displayCtl, ledOn
+		l.writeDisplay()
+		l.applyBacklight()
+		l.rewrite(ddram, addr, ctl)
+	})
+}
+
+// fillPattern writes the character returned by code to every cell of the display, without keeping
+// track of the content, which must be written again afterwards
+func (l *Device) fillPattern(code func(row, col uint8) byte) {
+	for r := uint8(0); r < l.rows; r++ {
+		for c := uint8(0); c < l.cols; c++ {
+			if c == 0 || !l.geo.follows(c) || l.entryMode&0b10 == 0 {
+				l.setAddr(l.geo.cellAddr(r, c))
+			}
+			l.write(code(r, c), cmdData)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package st7066u
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCharset is the characters a TextInput steps through by default, see func SetCharset
+const DefaultCharset = " ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789.,-_!?@#$%&*+=/:;()"
+
+// TextInput is an input field for text on one row of the display, e.g. for entering a PIN or a WiFi
+// password, with a visible cursor, and scrolling horizontally to keep the cursor shown when the text
+// is wider than the field. Text is entered with a keyboard by Insert and Delete, or with buttons or
+// a rotary encoder by stepping the character at the cursor through a charset with Increase and
+// Decrease, see func EditText of package input. Select confirms the text, and Back cancels the
+// editing, restoring the text it had. Use func NewTextInput to get a new struct
+type TextInput struct {
+	l               *Device
+	mu              sync.Mutex
+	row, col, width uint8
+	text            []rune
+	pos             int // Index of the rune at the cursor, len(text) at the end
+	scroll          int // Index of the first rune shown
+	charset         []rune
+	mask            rune // Shown instead of the runes other than at the cursor, 0 for none
+	maxLen          int
+	start           string // The text when the editing started
+	editing         bool
+	cursorOn        bool // The cursor was shown when the editing started
+}
+
+// NewTextInput returns a TextInput at the row and col, width cells wide, holding the text with the
+// cursor at its end. The field is drawn and starts editing, showing the cursor
+func (l *Device) NewTextInput(row, col, width uint8, text string) *TextInput {
+	t := &TextInput{
+		l:       l,
+		row:     row,
+		col:     col,
+		width:   width,
+		text:    []rune(text),
+		charset: []rune(DefaultCharset),
+	}
+	t.pos = len(t.text)
+	t.Edit()
+	return t
+}
+
+// SetCharset sets the characters that Increase and Decrease step through, e.g. "0123456789" for a
+// PIN. DefaultCharset by default
+func (t *TextInput) SetCharset(charset string) {
+	t.mu.Lock()
+	if cs := []rune(charset); len(cs) > 0 {
+		t.charset = cs
+	}
+	t.mu.Unlock()
+}
+
+// SetMask sets the rune shown instead of the characters of the text, other than the one at the
+// cursor, e.g. '*' for a password. 0, the default, shows the text as it is
+func (t *TextInput) SetMask(mask rune) {
+	t.mu.Lock()
+	t.mask = mask
+	t.mu.Unlock()
+	t.draw()
+}
+
+// SetMaxLength sets the max nr of runes of the text, e.g. 4 for a PIN. 0, the default, means no limit
+func (t *TextInput) SetMaxLength(n int) {
+	t.mu.Lock()
+	t.maxLen = n
+	t.mu.Unlock()
+}
+
+// Insert inserts the rune at the cursor and moves the cursor on, if editing and the text is shorter
+// than its max length
+func (t *TextInput) Insert(r rune) {
+	t.mu.Lock()
+	if !t.editing || t.maxLen > 0 && len(t.text) >= t.maxLen {
+		t.mu.Unlock()
+		return
+	}
+	t.text = append(t.text, 0)
+	copy(t.text[t.pos+1:], t.text[t.pos:])
+	t.text[t.pos] = r
+	t.pos++
+	t.mu.Unlock()
+	t.draw()
+}
+
+// Delete deletes the rune at the cursor, or the last rune if the cursor is at the end of the text, if
+// editing
+func (t *TextInput) Delete() {
+	t.mu.Lock()
+	if !t.editing || len(t.text) == 0 {
+		t.mu.Unlock()
+		return
+	}
+	if t.pos == len(t.text) {
+		t.pos--
+	}
+	t.text = append(t.text[:t.pos], t.text[t.pos+1:]...)
+	t.mu.Unlock()
+	t.draw()
+}
+
+// Left moves the cursor to the previous rune, if any
+func (t *TextInput) Left() {
+	t.move(-1)
+}
+
+// Right moves the cursor to the next rune, or to the end of the text after the last rune
+func (t *TextInput) Right() {
+	t.move(1)
+}
+
+// Increase steps the rune at the cursor to the next character of the charset, wrapping around, or
+// appends the first character of the charset at the end of the text, if editing
+func (t *TextInput) Increase() {
+	t.step(1)
+}
+
+// Decrease steps the rune at the cursor to the previous character of the charset, wrapping around,
+// or appends the last character of the charset at the end of the text, if editing
+func (t *TextInput) Decrease() {
+	t.step(-1)
+}
+
+// Edit starts editing the text, showing the cursor, if not editing already
+func (t *TextInput) Edit() {
+	t.mu.Lock()
+	if t.editing {
+		t.mu.Unlock()
+		return
+	}
+	t.editing, t.start = true, string(t.text)
+	t.cursorOn = t.l.IsCursorVisible()
+	t.mu.Unlock()
+	t.l.CursorOn(true)
+	t.draw()
+}
+
+// Select confirms the text and stops editing, hiding the cursor unless it was shown before
+func (t *TextInput) Select() {
+	t.stopEditing(false)
+}
+
+// Back cancels the editing, restoring the text it had when the editing started. false is returned if
+// not editing
+func (t *TextInput) Back() bool {
+	return t.stopEditing(true)
+}
+
+// Editing returns true if the text is being edited
+func (t *TextInput) Editing() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.editing
+}
+
+// Text returns the text
+func (t *TextInput) Text() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.text)
+}
+
+// State returns the position of the cursor and the text, e.g. `3 "1234"`, see type Scene. Mind that
+// the state holds the text as is, also if masked, e.g. a password
+func (t *TextInput) State() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return fmt.Sprintf("%d %q", t.pos, string(t.text))
+}
+
+// SetState sets the text and the cursor of a state returned by State, and draws the field
+func (t *TextInput) SetState(state string) error {
+	f := strings.SplitN(state, " ", 2)
+	if len(f) != 2 {
+		return fmt.Errorf("Invalid text input state %q", state)
+	}
+	pos, err := strconv.Atoi(f[0])
+	if err != nil {
+		return err
+	}
+	text, err := strconv.Unquote(f[1])
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	t.text = []rune(text)
+	if t.maxLen > 0 && len(t.text) > t.maxLen {
+		t.text = t.text[:t.maxLen]
+	}
+	if pos < 0 || pos > len(t.text) {
+		pos = len(t.text)
+	}
+	t.pos = pos
+	t.mu.Unlock()
+	t.draw()
+	return nil
+}
+
+// Redraw draws the field again, e.g. after the display has been used for something else
+func (t *TextInput) Redraw() {
+	t.draw()
+}
+
+// move moves the cursor by delta runes, within the text and its end
+func (t *TextInput) move(delta int) {
+	t.mu.Lock()
+	p := t.pos + delta
+	if !t.editing || p < 0 || p > len(t.text) || p == t.maxLen && t.maxLen > 0 {
+		t.mu.Unlock()
+		return
+	}
+	t.pos = p
+	t.mu.Unlock()
+	t.draw()
+}
+
+// step steps the rune at the cursor by delta characters of the charset while editing, appending a
+// rune at the end of the text
+func (t *TextInput) step(delta int) {
+	t.mu.Lock()
+	if !t.editing {
+		t.mu.Unlock()
+		return
+	}
+	n := len(t.charset)
+	if t.pos == len(t.text) {
+		if t.maxLen > 0 && len(t.text) >= t.maxLen {
+			t.mu.Unlock()
+			return
+		}
+		i := 0
+		if delta < 0 {
+			i = n - 1
+		}
+		t.text = append(t.text, t.charset[i])
+	} else {
+		i := 0
+		for j, r := range t.charset {
+			if r == t.text[t.pos] {
+				i = (j + delta%n + n) % n
+				break
+			}
+		}
+		t.text[t.pos] = t.charset[i]
+	}
+	t.mu.Unlock()
+	t.draw()
+}
+
+// stopEditing stops editing, restoring the text if cancel is true. false is returned if not editing
+func (t *TextInput) stopEditing(cancel bool) bool {
+	t.mu.Lock()
+	if !t.editing {
+		t.mu.Unlock()
+		return false
+	}
+	t.editing = false
+	if cancel {
+		t.text = []rune(t.start)
+		t.pos = len(t.text)
+	}
+	cursorOn := t.cursorOn
+	t.mu.Unlock()
+	t.draw()
+	t.l.CursorOn(cursorOn)
+	return true
+}
+
+// draw draws the field as it is now, scrolled to show the cursor, with the cursor of the display at
+// the cursor of the field while editing
+func (t *TextInput) draw() {
+	t.mu.Lock()
+	width := int(t.width)
+	if t.pos < t.scroll {
+		t.scroll = t.pos
+	}
+	if width > 0 && t.pos >= t.scroll+width {
+		t.scroll = t.pos - width + 1
+	}
+	if t.scroll > len(t.text) {
+		t.scroll = len(t.text)
+	}
+	shown := append([]rune(nil), t.text[t.scroll:]...)
+	if t.mask != 0 {
+		for i := range shown {
+			if t.scroll+i != t.pos || !t.editing {
+				shown[i] = t.mask
+			}
+		}
+	}
+	row, col, cursor, editing := t.row, t.col, t.col+uint8(t.pos-t.scroll), t.editing
+	t.mu.Unlock()
+	t.l.Update(func(f *Frame) {
+		f.printField(row, col, uint8(width), string(shown), false)
+		if editing {
+			f.SetCursor(row, cursor)
+		}
+	})
+}
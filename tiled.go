@@ -0,0 +1,148 @@
+package st7066u
+
+import (
+	"errors"
+	"sync"
+)
+
+// Tile is a display of a Tiled display, with the row and col of its top left in the Tiled display
+type Tile struct {
+	Device   *Device
+	Row, Col uint8
+}
+
+// tileRect is the cells of a Tile in a Tiled display, from top, left to bottom, right (exclusive)
+type tileRect struct {
+	top, left, bottom, right uint8
+}
+
+// contains returns true if the cell at the row and col is within the rect
+func (t *tileRect) contains(row, col uint8) bool {
+	return row >= t.top && row < t.bottom && col >= t.left && col < t.right
+}
+
+// Tiled is two or more displays used as one larger display, e.g. two 20x4 displays side by side as
+// one 40x4 display, or on top of each other as one 20x8 display. Use func NewTiled to get a new
+// struct
+type Tiled struct {
+	mu         sync.Mutex
+	tiles      []Tile
+	rects      []tileRect
+	frame      *Frame   // Frame of the whole Tiled display, reused by the updates
+	tileFrames []*Frame // Frame of each tile during an update, nil for a tile closed
+	rows, cols uint8
+}
+
+// NewTiled returns a Tiled display of the tiles, as large as needed to hold them all. An error is
+// returned if no tiles are given, if tiles overlap, or if the Tiled display would be larger than 255
+// rows or cols
+func NewTiled(tiles ...Tile) (*Tiled, error) {
+	if len(tiles) == 0 {
+		return nil, errors.New("No tiles given")
+	}
+	t := &Tiled{tiles: append([]Tile(nil), tiles...)}
+	rows, cols := 0, 0
+	for _, tile := range tiles {
+		r := tileRect{top: tile.Row, left: tile.Col}
+		if int(tile.Row)+int(tile.Device.rows) > 255 || int(tile.Col)+int(tile.Device.cols) > 255 {
+			return nil, errors.New("The tiled display must be at most 255 rows and cols")
+		}
+		r.bottom, r.right = tile.Row+tile.Device.rows, tile.Col+tile.Device.cols
+		for _, o := range t.rects {
+			if r.top < o.bottom && o.top < r.bottom && r.left < o.right && o.left < r.right {
+				return nil, errors.New("Tiles must not overlap")
+			}
+		}
+		t.rects = append(t.rects, r)
+		if int(r.bottom) > rows {
+			rows = int(r.bottom)
+		}
+		if int(r.right) > cols {
+			cols = int(r.right)
+		}
+	}
+	t.rows, t.cols = uint8(rows), uint8(cols)
+	t.frame = &Frame{rows: t.rows, cols: t.cols, cells: make([][]byte, t.rows), l: tiles[0].Device, tiled: t}
+	for r := range t.frame.cells {
+		t.frame.cells[r] = make([]byte, t.cols)
+	}
+	t.frame.Clear()
+	t.tileFrames = make([]*Frame, len(tiles))
+	return t, nil
+}
+
+// Rows returns the nr of rows of the Tiled display
+func (t *Tiled) Rows() uint8 {
+	return t.rows
+}
+
+// Cols returns the nr of columns of the Tiled display
+func (t *Tiled) Cols() uint8 {
+	return t.cols
+}
+
+// Update stages the changes made by fn to a Frame of the whole Tiled display, and writes the
+// characters that changed to the displays they are on, as Device.Update does. fn is run once, while
+// holding all displays, so it must not call any methods of the displays. Registered glyphs are
+// loaded into the displays they are printed on. The cursor of the frame is moved to the display it
+// is on. Cells not on any display are dropped
+func (t *Tiled) Update(fn func(f *Frame)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.update(0, fn)
+}
+
+// update holds the displays from the i:th tile on, one at a time, and then runs fn on the frame of
+// the whole Tiled display, see func Update. Tiles closed are skipped
+func (t *Tiled) update(i int, fn func(f *Frame)) {
+	if i < len(t.tiles) {
+		l := t.tiles[i].Device
+		held := false
+		l.doWait(func() {
+			l.update(func(df *Frame) {
+				held = true
+				t.tileFrames[i] = df
+				t.update(i+1, fn)
+			})
+		})
+		if !held {
+			t.tileFrames[i] = nil
+			t.update(i+1, fn)
+		}
+		return
+	}
+	f := t.frame
+	for i, df := range t.tileFrames {
+		if df == nil {
+			continue
+		}
+		r := t.rects[i]
+		for row := range df.cells {
+			copy(f.cells[int(r.top)+row][r.left:], df.cells[row])
+		}
+	}
+	fn(f)
+	for i, df := range t.tileFrames {
+		if df == nil {
+			continue
+		}
+		r := t.rects[i]
+		for row := range df.cells {
+			copy(df.cells[row], f.cells[int(r.top)+row][r.left:r.right])
+		}
+		if r.contains(f.row, f.col) {
+			df.row, df.col = f.row-r.top, f.col-r.left
+		}
+	}
+}
+
+// PrintAt prints the text at the row and col of the Tiled display, continuing on the display to the
+// right, if any
+func (t *Tiled) PrintAt(row, col uint8, text string) {
+	t.Update(func(f *Frame) { f.PrintAt(row, col, text) })
+}
+
+// Clear clears all displays
+func (t *Tiled) Clear() {
+	t.Update(func(f *Frame) { f.Clear() })
+}
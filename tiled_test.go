@@ -0,0 +1,79 @@
+package st7066u
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTiled(t *testing.T) {
+	printRows := func(f *Frame) {
+		for r := uint8(0); r < f.rows; r++ {
+			f.PrintAt(r, 0, fmt.Sprintf("Row %d", r))
+		}
+	}
+	tests := []struct {
+		name     string
+		row, col uint8 // Top left of the second display
+		fns      []func(f *Frame)
+		want     [2][]string
+	}{
+		{"stacked", 4, 0, []func(f *Frame){printRows},
+			[2][]string{{"Row 0", "Row 1", "Row 2", "Row 3"}, {"Row 4", "Row 5", "Row 6", "Row 7"}}},
+		{"stacked, scrolled", 4, 0, []func(f *Frame){printRows, (*Frame).ScrollUp},
+			[2][]string{{"Row 1", "Row 2", "Row 3", "Row 4"}, {"Row 5", "Row 6", "Row 7", ""}}},
+		{"side by side", 0, 20, []func(f *Frame){func(f *Frame) { f.PrintAt(1, 15, "Hello, world") }},
+			[2][]string{{"", "               Hello", "", ""}, {"", ", world", "", ""}}},
+		{"side by side, scrolled", 0, 20, []func(f *Frame){
+			func(f *Frame) { f.PrintAt(1, 15, "Hello, world") },
+			(*Frame).ScrollUp,
+		}, [2][]string{{"               Hello", "", "", ""}, {", world", "", "", ""}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d0, s0 := newTestDevice(t, "2004", BITMODE8)
+			d1, s1 := newTestDevice(t, "2004", BITMODE8)
+			tl, err := NewTiled(Tile{Device: d0}, Tile{Device: d1, Row: tt.row, Col: tt.col})
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, fn := range tt.fns {
+				runs := 0
+				tl.Update(func(f *Frame) {
+					runs++
+					fn(f)
+				})
+				if runs != 1 {
+					t.Errorf("fn run %d times, want once", runs)
+				}
+			}
+			checkLines(t, s0, tt.want[0]...)
+			checkLines(t, s1, tt.want[1]...)
+		})
+	}
+}
+
+func TestTiledGlyphs(t *testing.T) {
+	d0, s0 := newTestDevice(t, "2004", BITMODE8)
+	d1, s1 := newTestDevice(t, "2004", BITMODE8)
+	tl, err := NewTiled(Tile{Device: d0}, Tile{Device: d1, Row: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diamond := Glyph{0x00, 0x04, 0x0e, 0x1f, 0x0e, 0x04, 0x00, 0x00}
+	for _, d := range []*Device{d0, d1} {
+		d.RegisterGlyph('♥', heart)
+		d.RegisterGlyph('♦', diamond)
+	}
+	tl.Update(func(f *Frame) {
+		f.PrintAt(7, 0, "♦")
+		f.PrintAt(4, 0, "♥")
+	})
+	tl.Update((*Frame).ScrollUp)
+	checkLines(t, s0, "", "", "", "\x00")
+	checkLines(t, s1, "", "", "\x00", "")
+	checkShadow(t, d0, s0)
+	checkShadow(t, d1, s1)
+	if got := d0.slots[0].r; got != '♥' {
+		t.Errorf("Top display: slot 0 holds %q, want '♥'", got)
+	}
+}
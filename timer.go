@@ -0,0 +1,314 @@
+package st7066u
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Countdown is a countdown timer on the display, see func Countdown
+type Countdown struct {
+	l        *Device
+	row, col uint8
+	onDone   func()
+	mu       sync.Mutex
+	left     time.Duration // Time left when started or paused
+	started  time.Time
+	paused   bool
+	width    uint8 // Width of the widest text written, to clear what is left of a wider text
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Countdown shows a countdown from the duration d at the row and col, e.g. "05:00" for 5 minutes,
+// and "1:30:00" for durations of an hour or more. The time left is shown rounded up to whole seconds
+// and updated by a goroutine on each second boundary, writing only the characters that changed. When
+// the countdown reaches 0, onDone is called (if not nil) from the goroutine, e.g. to blink the
+// backlight. Call Stop on the returned struct to stop the countdown, without calling onDone
+func (l *Device) Countdown(row, col uint8, d time.Duration, onDone func()) *Countdown {
+	c := &Countdown{
+		l:       l,
+		row:     row,
+		col:     col,
+		onDone:  onDone,
+		left:    d,
+		started: time.Now(),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go c.run()
+	return c
+}
+
+// Pause pauses the countdown
+func (c *Countdown) Pause() {
+	c.mu.Lock()
+	if !c.paused {
+		c.left, c.paused = c.remaining(), true
+	}
+	c.mu.Unlock()
+	wakeUp(c.wake)
+}
+
+// Resume resumes the countdown after Pause
+func (c *Countdown) Resume() {
+	c.mu.Lock()
+	if c.paused {
+		c.started, c.paused = time.Now(), false
+	}
+	c.mu.Unlock()
+	wakeUp(c.wake)
+}
+
+// Remaining returns the time left of the countdown
+func (c *Countdown) Remaining() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remaining()
+}
+
+// State returns the time left and whether the countdown is paused, e.g. "1m30s false", see type Scene
+func (c *Countdown) State() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return fmt.Sprintf("%s %t", c.remaining().Round(time.Millisecond), c.paused)
+}
+
+// SetState sets the time left of a state returned by State, pausing the countdown if it was paused.
+// The time the program wasn't running isn't counted, and a countdown done or stopped isn't started
+// again
+func (c *Countdown) SetState(state string) error {
+	f := strings.Fields(state)
+	if len(f) != 2 {
+		return fmt.Errorf("Invalid countdown state %q", state)
+	}
+	left, err := time.ParseDuration(f[0])
+	if err != nil {
+		return err
+	}
+	paused, err := strconv.ParseBool(f[1])
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.left, c.started, c.paused = left, time.Now(), paused
+	c.mu.Unlock()
+	wakeUp(c.wake)
+	return nil
+}
+
+// Stop stops the countdown, leaving the time left shown, without calling onDone
+func (c *Countdown) Stop() {
+	c.stopOnce.Do(func() { close(c.stop) })
+	<-c.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (c *Countdown) Done() <-chan struct{} {
+	return c.done
+}
+
+// remaining returns the time left of the countdown, 0 at the least
+func (c *Countdown) remaining() time.Duration {
+	left := c.left
+	if !c.paused {
+		left -= time.Since(c.started)
+	}
+	if left < 0 {
+		left = 0
+	}
+	return left
+}
+
+// run shows the time left on each second boundary until the countdown is done or stopped
+func (c *Countdown) run() {
+	defer close(c.done)
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		c.mu.Lock()
+		left, paused := c.remaining(), c.paused
+		c.mu.Unlock()
+		shown := (left + time.Second - 1).Truncate(time.Second)
+		text := fmtTimer(shown, false)
+		if len(text) > int(c.width) {
+			c.width = uint8(len(text))
+		}
+		row, col, width := c.row, c.col, c.width
+		c.l.Update(func(f *Frame) { f.printField(row, col, width, text, false) })
+		if left == 0 {
+			if c.onDone != nil {
+				c.onDone()
+			}
+			return
+		}
+		wait := time.Hour
+		if !paused {
+			wait = left - shown + time.Second
+		}
+		resetTimer(timer, wait)
+		select {
+		case <-c.stop:
+			return
+		case <-c.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// Stopwatch is a stopwatch on the display, see func Stopwatch
+type Stopwatch struct {
+	l        *Device
+	row, col uint8
+	mu       sync.Mutex
+	elapsed  time.Duration // Time elapsed when started or paused
+	started  time.Time
+	paused   bool
+	width    uint8 // Width of the widest text written, to clear what is left of a wider text
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// Stopwatch starts a stopwatch at the row and col, showing the time elapsed with tenths of seconds,
+// e.g. "01:23.4", and "1:01:23.4" from an hour. It is updated by a goroutine on each tenth of a
+// second, writing only the characters that changed. Call Stop on the returned struct to stop
+// updating the stopwatch, leaving the time elapsed shown
+func (l *Device) Stopwatch(row, col uint8) *Stopwatch {
+	s := &Stopwatch{
+		l:       l,
+		row:     row,
+		col:     col,
+		started: time.Now(),
+		wake:    make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// Pause pauses the stopwatch
+func (s *Stopwatch) Pause() {
+	s.mu.Lock()
+	if !s.paused {
+		s.elapsed, s.paused = s.sinceStart(), true
+	}
+	s.mu.Unlock()
+	wakeUp(s.wake)
+}
+
+// Resume resumes the stopwatch after Pause
+func (s *Stopwatch) Resume() {
+	s.mu.Lock()
+	if s.paused {
+		s.started, s.paused = time.Now(), false
+	}
+	s.mu.Unlock()
+	wakeUp(s.wake)
+}
+
+// Reset sets the time elapsed to 0, keeping the stopwatch running or paused
+func (s *Stopwatch) Reset() {
+	s.mu.Lock()
+	s.elapsed, s.started = 0, time.Now()
+	s.mu.Unlock()
+	wakeUp(s.wake)
+}
+
+// Elapsed returns the time elapsed
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sinceStart()
+}
+
+// Stop stops updating the stopwatch, leaving the time elapsed shown
+func (s *Stopwatch) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+// Done returns a channel closed when the goroutine has ended, see type Stopper
+func (s *Stopwatch) Done() <-chan struct{} {
+	return s.done
+}
+
+// sinceStart returns the time elapsed
+func (s *Stopwatch) sinceStart() time.Duration {
+	if s.paused {
+		return s.elapsed
+	}
+	return s.elapsed + time.Since(s.started)
+}
+
+// run shows the time elapsed on each tenth of a second, until stopped
+func (s *Stopwatch) run() {
+	defer close(s.done)
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		s.mu.Lock()
+		elapsed, paused := s.sinceStart(), s.paused
+		s.mu.Unlock()
+		text := fmtTimer(elapsed, true)
+		if len(text) > int(s.width) {
+			s.width = uint8(len(text))
+		}
+		row, col, width := s.row, s.col, s.width
+		s.l.Update(func(f *Frame) { f.printField(row, col, width, text, false) })
+		wait := time.Hour
+		if !paused {
+			tenth := time.Second / 10
+			wait = elapsed.Truncate(tenth) + tenth - elapsed
+		}
+		resetTimer(timer, wait)
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+	}
+}
+
+// fmtTimer formats the duration as minutes and seconds, e.g. "05:00", with hours from an hour, e.g.
+// "1:05:00", and with tenths of seconds if tenths is true, e.g. "05:00.0"
+func fmtTimer(d time.Duration, tenths bool) string {
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	text := fmt.Sprintf("%02d:%02d", m, s)
+	if h > 0 {
+		text = fmt.Sprintf("%d:%s", h, text)
+	}
+	if tenths {
+		text += fmt.Sprintf(".%d", int(d/(time.Second/10))%10)
+	}
+	return text
+}
+
+// wakeUp wakes a goroutine waiting for the channel, unless already woken
+func wakeUp(wake chan struct{}) {
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// resetTimer resets the timer to fire after d, draining it if it has fired and not been received
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(d)
+}
@@ -0,0 +1,156 @@
+package st7066u
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// keyTiming is the key of the timing persisted by the device, see func SetStore
+const keyTiming = "timing"
+
+// calibrationRounds is the nr of patterns written and read back to accept a timing as reliable, and
+// calibrationMargin the margin added to the shortest reliable times found
+const (
+	calibrationRounds = 4
+	calibrationMargin = 1.25
+)
+
+// Timing is the timing of the writes to the display; how long E is held high (and the data held
+// before it goes high), and how long is waited after each write for the controller to execute it.
+// The defaults follow the datasheet, but many panels are faster, and some slower. See func SetTiming
+// and CalibrateTiming
+type Timing struct {
+	EPulse time.Duration // Width of the E pulse, and setup time of the data before it
+	EWait  time.Duration // Wait after each write, for the controller to execute the instruction
+}
+
+// DefaultTiming is the timing of the datasheet, used unless set by SetTiming
+var DefaultTiming = Timing{EPulse: pinEDelay, EWait: pinEWait}
+
+// String returns the timing as the E pulse width and the wait, e.g. "1µs 70µs"
+func (t Timing) String() string {
+	return t.EPulse.String() + " " + t.EWait.String()
+}
+
+// ParseTiming parses a timing formatted as by Timing.String, e.g. "1µs 70µs"
+func ParseTiming(s string) (Timing, error) {
+	f := strings.Fields(s)
+	if len(f) != 2 {
+		return Timing{}, fmt.Errorf("Invalid timing %q", s)
+	}
+	pulse, err := time.ParseDuration(f[0])
+	if err != nil {
+		return Timing{}, err
+	}
+	wait, err := time.ParseDuration(f[1])
+	if err != nil {
+		return Timing{}, err
+	}
+	return Timing{EPulse: pulse, EWait: wait}, nil
+}
+
+// SetTiming sets the timing of the writes to the display, e.g. as found by CalibrateTiming for the
+// panel. Shorter times write faster, but too short times garble what is written. The waits during
+// initialization and after clearing the display are not changed
+func (l *Device) SetTiming(t Timing) {
+	l.do(func() {
+		l.timing = t
+	})
+}
+
+// Timing returns the timing of the writes to the display, see SetTiming
+func (l *Device) Timing() Timing {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.timing
+}
+
+// CalibrateTiming finds the shortest timing with which the panel reliably reads back what is
+// written, by binary search, first of the wait after each write and then of the E pulse, and sets
+// it with a margin of 25%. Requires the R/W pin (see SetRWPin), as patterns are written to the CGRAM
+// and read back; with two controllers, the first is calibrated. Writes that fail may garble the
+// display, which is then initialized again and its content restored, so run it e.g. at the start of
+// the program, or once per panel with the timing persisted. The timing found is saved to the Store,
+// if one is set (see SetStore), and restored from it by SetStore. As time.Sleep may sleep longer than
+// asked for, the timing found is the shortest the host can ask for reliably, not the shortest of
+// the panel
+func (l *Device) CalibrateTiming() (Timing, error) {
+	var t Timing
+	err := errNoRW
+	l.doWait(func() {
+		if l.pinRW == nil {
+			return
+		}
+		err = nil
+		safe := l.timing
+		if !l.timingWorks(safe, safe) {
+			err = errors.New("The display doesn't read back what is written with the current timing")
+			return
+		}
+		t = safe
+		t.EWait = searchMin(0, safe.EWait, time.Microsecond, func(d time.Duration) bool {
+			return l.timingWorks(Timing{EPulse: safe.EPulse, EWait: d}, safe)
+		})
+		t.EPulse = searchMin(0, safe.EPulse, time.Nanosecond*10, func(d time.Duration) bool {
+			return l.timingWorks(Timing{EPulse: d, EWait: t.EWait}, safe)
+		})
+		t.EPulse = time.Duration(float64(t.EPulse) * calibrationMargin)
+		t.EWait = time.Duration(float64(t.EWait) * calibrationMargin)
+		l.timing = t
+	})
+	if err != nil {
+		return Timing{}, err
+	}
+	return t, l.saveValue(keyTiming, t.String())
+}
+
+// timingWorks returns true if patterns written to the CGRAM with the timing t are read back, and
+// restores the timing safe. If they aren't, the display is initialized again and its content
+// restored
+func (l *Device) timingWorks(t, safe Timing) bool {
+	addr, ctl, held := l.addr, l.ctl, l.teeHeld
+	l.teeHeld = true
+	defer func() { l.teeHeld = held }()
+	l.selectCtl(0)
+	ok := true
+	for round := 0; round < calibrationRounds && ok; round++ {
+		pattern := func(i int) byte { return byte(i*7+round*13) & 0x1f }
+		l.timing = t
+		l.write(0x40, cmdInstruction)
+		for i := 0; i < 0x40; i++ {
+			l.write(pattern(i), cmdData)
+		}
+		l.timing = safe
+		l.write(0x40, cmdInstruction)
+		for i := 0; i < 0x40 && ok; i++ {
+			ok = l.read(cmdData)&0x1f == pattern(i)
+		}
+	}
+	l.timing = safe
+	if !ok {
+		l.reinit(true)
+		return false
+	}
+	l.writeGlyphs()
+	l.restoreAddrs(addr, ctl)
+	return true
+}
+
+// searchMin returns the shortest duration from lo to hi, with the resolution, for which ok returns
+// true, assuming it does for hi and for all durations longer than one it does for
+func searchMin(lo, hi, resolution time.Duration, ok func(d time.Duration) bool) time.Duration {
+	for hi-lo > resolution {
+		mid := lo + (hi-lo)/2
+		if ok(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	if ok(lo) {
+		return lo
+	}
+	return hi
+}
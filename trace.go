@@ -0,0 +1,148 @@
+package st7066u
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// tracer decodes the bytes written to the display as text, see func SetTrace
+type tracer struct {
+	w  io.Writer
+	cg [2]bool // The address counter of each controller points into CGRAM
+}
+
+// NewTrace returns a Device that isn't connected to any display, but decodes every byte written to
+// it as text written to w, as SetTrace does, from the initialization on. This allows layout code to
+// be debugged without any hardware, e.g. in CI. Arguments are the same as for New, apart from the
+// pins
+func NewTrace(nrOfRows, nrOfCols uint8, charSym uint8, mode uint8, w io.Writer) (*Device, error) {
+	if err := validateSymm(nrOfRows, nrOfCols, charSym); err != nil {
+		return nil, err
+	}
+	nrs := 8
+	if mode == BITMODE4 {
+		nrs = 4
+	}
+	if err := validatePinMode(mode, nrs); err != nil {
+		return nil, err
+	}
+	pins := make([]Pin, nrs)
+	for i := range pins {
+		pins[i] = noPin{}
+	}
+	g := newDevice(newGeometry(nrOfRows, nrOfCols), charSym, noPin{}, noPin{}, noPin{}, pins)
+	g.backend = "trace"
+	g.trace = &tracer{w: w}
+	g.setDefaultMasks()
+	g.start()
+	return g, nil
+}
+
+// SetTrace sets a writer to which every byte written to the display is written decoded as a line
+// of text, e.g. "SET DDRAM 0x40" for an instruction setting the address, and "DATA 'H'" for the
+// character H. With two controllers, each line starts with the controller written to, "E1: ",
+// "E2: " or "E*: " for both. nil stops the trace. Errors writing to w are ignored. Nothing is traced
+// in bounded memory mode, see SetBoundedMemory
+func (l *Device) SetTrace(w io.Writer) {
+	l.do(func() {
+		l.trace = nil
+		if w != nil && !l.bounded {
+			l.trace = &tracer{w: w}
+		}
+	})
+}
+
+// traceWrite writes the byte decoded to the trace
+func (l *Device) traceWrite(data uint8, cmd uint8, ctl int) {
+	t := l.trace
+	var b strings.Builder
+	if l.pinE2 != nil {
+		if ctl < 0 {
+			b.WriteString("E*: ")
+		} else {
+			fmt.Fprintf(&b, "E%d: ", ctl+1)
+		}
+	}
+	cg := t.cg[0]
+	if ctl > 0 {
+		cg = t.cg[ctl]
+	}
+	if cmd == cmdData {
+		b.WriteString(decodeData(data, l.romRune(data), cg))
+	} else {
+		b.WriteString(decodeInstruction(data))
+		if data&0xc0 != 0 {
+			cg = data&0x80 == 0
+			if ctl != 1 {
+				t.cg[0] = cg
+			}
+			if ctl != 0 {
+				t.cg[1] = cg
+			}
+		}
+	}
+	b.WriteByte('\n')
+	io.WriteString(t.w, b.String())
+}
+
+// decodeData returns the data byte decoded as text, as the character r of the ROM, or a row of a
+// glyph if cg is true
+func decodeData(data uint8, r rune, cg bool) string {
+	if cg {
+		var row strings.Builder
+		for i := 4; i >= 0; i-- {
+			if data&(1<<i) != 0 {
+				row.WriteByte('X')
+			} else {
+				row.WriteByte('.')
+			}
+		}
+		return fmt.Sprintf("DATA 0x%02x %s", data, row.String())
+	}
+	if data >= 0x20 && (r != '?' || data == '?') && unicode.IsPrint(r) {
+		return fmt.Sprintf("DATA %q", r)
+	}
+	return fmt.Sprintf("DATA 0x%02x", data)
+}
+
+// decodeInstruction returns the instruction decoded as text
+func decodeInstruction(data uint8) string {
+	onOff := func(name string, bit uint8) string {
+		if data&bit != 0 {
+			return name + " ON"
+		}
+		return name + " OFF"
+	}
+	either := func(bit uint8, set, clear string) string {
+		if data&bit != 0 {
+			return set
+		}
+		return clear
+	}
+	switch {
+	case data&0x80 != 0:
+		return fmt.Sprintf("SET DDRAM 0x%02x", data&0x7f)
+	case data&0x40 != 0:
+		return fmt.Sprintf("SET CGRAM 0x%02x", data&0x3f)
+	case data&0x20 != 0:
+		return strings.Join([]string{
+			"FUNCTION SET",
+			either(0x10, "8-BIT", "4-BIT"),
+			either(0x08, "2 LINES", "1 LINE"),
+			either(0x04, "5x11", "5x8"),
+		}, " ")
+	case data&0x10 != 0:
+		return either(0x08, "SHIFT DISPLAY", "MOVE CURSOR") + either(0x04, " RIGHT", " LEFT")
+	case data&0x08 != 0:
+		return "DISPLAY " + strings.Join([]string{either(0x04, "ON", "OFF"), onOff("CURSOR", 0x02), onOff("BLINK", 0x01)}, " ")
+	case data&0x04 != 0:
+		return "ENTRY MODE " + either(0x02, "INCREMENT", "DECREMENT") + either(0x01, " SHIFT", "")
+	case data&0x02 != 0:
+		return "RETURN HOME"
+	case data&0x01 != 0:
+		return "CLEAR DISPLAY"
+	}
+	return "NOP"
+}
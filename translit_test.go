@@ -0,0 +1,33 @@
+package st7066u
+
+import "testing"
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name  string
+		on    bool
+		setup func(d *Device)
+		text  string
+		want  string
+	}{
+		{"off", false, nil, "€5 ő", "?5 ?"},
+		{"on", true, nil, "€5 ő…", "EUR5 o..."},
+		{"quotes", true, nil, "“Hi” ‘x’", "\"Hi\" 'x'"},
+		{"in the ROM", true, nil, "ä ß ñ", "ä ß ñ"},
+		{"no transliteration", true, nil, "✓", "?"},
+		{"registered glyph", true, func(d *Device) { d.RegisterGlyph('€', heart) }, "€5", "\x005"},
+		{"mapped rune", true, func(d *Device) { d.MapRune('€', 'E') }, "€5", "E5"},
+		{"fallback glyph", true, func(d *Device) { d.SetGlyphFallback(true) }, "ő", "\x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, s := newTestDevice(t, "1602", BITMODE8)
+			d.SetTransliterate(tt.on)
+			if tt.setup != nil {
+				tt.setup(d)
+			}
+			d.Print(tt.text)
+			checkLines(t, s, tt.want)
+		})
+	}
+}
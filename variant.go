@@ -0,0 +1,156 @@
+package st7066u
+
+import (
+	"errors"
+	"time"
+)
+
+// Variant is the controller of the display, for displays with a controller that is compatible with,
+// but not identical to, the ST7066U. See func SetVariant
+type Variant uint8
+
+// ST7066U, US2066, WS0010, ST7036 and SPLC780D; the supported controller variants
+const (
+	ST7066U  Variant = iota // ST7066U, HD44780 and compatible controllers (default)
+	US2066                  // OLED controllers US2066 and SSD1311
+	WS0010                  // OLED controller WS0010, as used by Winstar WEH displays
+	ST7036                  // ST7036, as used by 3.3V displays such as the EA DOGM series
+	SPLC780D                // SPLC780D, HD44780 compatible but slower during initialization
+)
+
+// st7036PowerWait is the time for the voltage of the ST7036 to stabilize after the follower is on
+const st7036PowerWait = time.Millisecond * 200
+
+// Instructions of the US2066 used by the device
+const (
+	us2066FunctionA = 0x71 // Function selection A, followed by a data byte
+	us2066FunctionB = 0x72 // Function selection B, followed by a data byte
+	us2066SDOn      = 0x79 // Enables the OLED command set
+	us2066SDOff     = 0x78
+	us2066Contrast  = 0x81 // OLED command, followed by the contrast
+)
+
+// SetVariant sets the controller of the display, and initializes the display again for it, keeping
+// what is shown. OLED displays sold as drop-in replacements (US2066, SSD1311, WS0010) need extra
+// instructions to power up, the ST7036 needs its bias, booster and contrast to be set (for 3.3V
+// operation) to show anything, and the SPLC780D needs longer waits while initializing. The US2066
+// and ST7036 support setting the contrast, see SetContrast. Graphic mode of the WS0010 is not
+// supported. Variants are not simulated
+func (l *Device) SetVariant(v Variant) error {
+	if v > SPLC780D {
+		return errors.New("Unknown controller variant")
+	}
+	if l.backend == "simulator" {
+		return errors.New("Controller variants are not simulated")
+	}
+	l.do(func() {
+		l.variant = v
+		l.setFontTable()
+		l.reinit(true)
+	})
+	return nil
+}
+
+// contrastVariant reports if the controller variant supports setting the contrast
+func (l *Device) contrastVariant() bool {
+	return l.variant == US2066 || l.variant == ST7036
+}
+
+// writeContrast writes the contrast to all controllers, if the controller variant supports it
+func (l *Device) writeContrast() {
+	all := l.all
+	l.all = true
+	switch l.variant {
+	case US2066:
+		for _, ins := range []uint8{l.extFunctionSet(), us2066SDOn, us2066Contrast, uint8(l.contrast*0xff + 0.5), us2066SDOff, l.functionSet} {
+			l.write(ins, cmdInstruction)
+		}
+	case ST7036:
+		l.write(l.functionSet|1, cmdInstruction)
+		l.writeST7036Contrast()
+		l.write(l.functionSet, cmdInstruction)
+	}
+	l.all = all
+}
+
+// extFunctionSet returns the function set instruction selecting the extended instruction set of the
+// US2066 (RE set), where the bit of the 5x11 font is used for blinking instead
+func (l *Device) extFunctionSet() uint8 {
+	return l.functionSet&^0b100 | 0b10
+}
+
+// initVariant writes the instructions needed by the controller variant after the mode is set,
+// to all controllers
+func (l *Device) initVariant() {
+	switch l.variant {
+	case US2066:
+		l.initUS2066()
+	case WS0010:
+		l.write(0x17, cmdInstruction) // Character mode, internal power on
+		time.Sleep(pinEWait)
+	case ST7036:
+		l.initST7036()
+	}
+}
+
+// initWaits returns the waits after each of the first three function set instructions while
+// initializing, see func setMode
+func (l *Device) initWaits() []time.Duration {
+	if l.variant == SPLC780D {
+		return []time.Duration{initWait1 * 2, initWait2 * 2, initWait2}
+	}
+	return []time.Duration{initWait1, initWait2, pinEWait}
+}
+
+// initST7036 sets the bias, booster, follower and contrast of an ST7036 controller for 3.3V
+// operation, following the initialization of the datasheet
+func (l *Device) initST7036() {
+	l.write(l.functionSet|1, cmdInstruction) // Instruction table 1
+	l.write(0x14, cmdInstruction)            // 1/5 bias
+	l.writeST7036Contrast()
+	l.write(0x6d, cmdInstruction) // Follower on, amplified ratio
+	time.Sleep(st7036PowerWait)
+	l.write(l.functionSet, cmdInstruction)
+	time.Sleep(pinEWait)
+}
+
+// writeST7036Contrast writes the contrast, with the booster on, to an ST7036 controller. Instruction
+// table 1 must be selected
+func (l *Device) writeST7036Contrast() {
+	c := uint8(l.contrast*0x3f + 0.5)
+	l.write(0x54|c>>4, cmdInstruction) // Booster on, contrast bits 5 - 4
+	l.write(0x70|c&0xf, cmdInstruction)
+}
+
+// initUS2066 powers up the OLED of a US2066 (or SSD1311) controller, following the initialization
+// of the datasheet. The internal regulator is enabled, as needed with 5V I/O
+func (l *Device) initUS2066() {
+	fs, ext := l.functionSet, l.extFunctionSet()
+	data := func(ins, b uint8) {
+		l.write(ins, cmdInstruction)
+		l.write(b, cmdData)
+	}
+	l.write(ext, cmdInstruction)
+	data(us2066FunctionA, 0x5c)
+	for _, ins := range []uint8{
+		fs, 0x08, // Display off
+		ext, us2066SDOn, 0xd5, 0x70, us2066SDOff, // Clock divide ratio and oscillator frequency
+		0x08, // 5 dot font, 1 or 2 lines
+		0x06, // COM and SEG scan direction
+	} {
+		l.write(ins, cmdInstruction)
+	}
+	data(us2066FunctionB, 0x00) // ROM A, 8 user-defined characters
+	for _, ins := range []uint8{
+		us2066SDOn,
+		0xda, 0x10, // SEG pins hardware configuration
+		0xdc, 0x00, // Internal VSL
+		us2066Contrast, uint8(l.contrast*0xff + 0.5),
+		0xd9, 0xf1, // Phase length
+		0xdb, 0x40, // VCOMH deselect level
+		us2066SDOff, fs,
+	} {
+		l.write(ins, cmdInstruction)
+	}
+	time.Sleep(pinEWait)
+}
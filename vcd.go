@@ -0,0 +1,172 @@
+package st7066u
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// PinRecording holds the transitions of the RS, E and data pins recorded while writing to the
+// display, see func RecordPins. Use WriteVCD to export them
+type PinRecording struct {
+	dev     *Device
+	mu      sync.Mutex
+	names   []string
+	start   time.Time
+	changes []pinChange
+}
+
+// pinChange is one recorded transition of a pin
+type pinChange struct {
+	at   time.Duration
+	sig  int
+	high bool
+}
+
+// recPin is a pin recording its transitions to a PinRecording
+type recPin struct {
+	Pin
+	rec *PinRecording
+	sig int
+}
+
+// RecordPins starts recording all transitions of the RS, E and data pins, with timestamps, until
+// Stop is called on the returned struct. Any previous recording is stopped. The recording can be
+// exported in the VCD (Value Change Dump) format, e.g. for viewing in GTKWave or comparing to a
+// capture of a logic analyzer. Nothing is recorded in bounded memory mode, see SetBoundedMemory
+func (l *Device) RecordPins() *PinRecording {
+	r := &PinRecording{dev: l}
+	l.do(func() {
+		l.unwrapPins()
+		r.start = time.Now()
+		if l.bounded {
+			return
+		}
+		l.pinRS = r.wrap("RS", l.pinRS)
+		l.pinE = r.wrap("E", l.pinE)
+		if l.pinE2 != nil {
+			l.pinE2 = r.wrap("E2", l.pinE2)
+		}
+		if l.pinRW != nil {
+			l.pinRW = r.wrap("RW", l.pinRW)
+		}
+		first := 0
+		if l.mode == BITMODE4 {
+			first = 4
+		}
+		for i, p := range l.pinDs {
+			l.pinDs[i] = r.wrap(fmt.Sprintf("D%d", first+i), p)
+		}
+		l.rec = r
+	})
+	return r
+}
+
+// Stop stops the recording
+func (r *PinRecording) Stop() {
+	l := r.dev
+	l.do(func() {
+		if l.rec == r {
+			l.unwrapPins()
+		}
+	})
+}
+
+// WriteVCD writes the recorded transitions in the VCD (Value Change Dump) format, with a timescale
+// of 1 ns. The state of all pins is unknown until their first transition
+func (r *PinRecording) WriteVCD(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "$date %s $end\n", r.start.Format(time.RFC1123))
+	fmt.Fprintf(b, "$version go-st7066u $end\n")
+	fmt.Fprintf(b, "$timescale 1ns $end\n")
+	fmt.Fprintf(b, "$scope module lcd $end\n")
+	for sig, name := range r.names {
+		fmt.Fprintf(b, "$var wire 1 %s %s $end\n", vcdID(sig), name)
+	}
+	fmt.Fprintf(b, "$upscope $end\n$enddefinitions $end\n")
+	fmt.Fprintf(b, "#0\n$dumpvars\n")
+	for sig := range r.names {
+		fmt.Fprintf(b, "x%s\n", vcdID(sig))
+	}
+	fmt.Fprintf(b, "$end\n")
+	last := time.Duration(-1)
+	for _, c := range r.changes {
+		if c.at != last {
+			fmt.Fprintf(b, "#%d\n", c.at.Nanoseconds())
+			last = c.at
+		}
+		v := '0'
+		if c.high {
+			v = '1'
+		}
+		fmt.Fprintf(b, "%c%s\n", v, vcdID(c.sig))
+	}
+	return b.Flush()
+}
+
+// High implements Pin
+func (p *recPin) High() {
+	p.Pin.High()
+	p.rec.add(p.sig, true)
+}
+
+// Low implements Pin
+func (p *recPin) Low() {
+	p.Pin.Low()
+	p.rec.add(p.sig, false)
+}
+
+// Input implements inPin
+func (p *recPin) Input() {
+	p.Pin.(inPin).Input()
+}
+
+// Read implements inPin. Reads are not recorded
+func (p *recPin) Read() pinState {
+	return p.Pin.(inPin).Read()
+}
+
+// add records a transition of the signal
+func (r *PinRecording) add(sig int, high bool) {
+	r.mu.Lock()
+	r.changes = append(r.changes, pinChange{at: time.Since(r.start), sig: sig, high: high})
+	r.mu.Unlock()
+}
+
+// wrap returns the pin wrapped to record its transitions as a signal with the name
+func (r *PinRecording) wrap(name string, p Pin) Pin {
+	r.names = append(r.names, name)
+	return &recPin{Pin: p, rec: r, sig: len(r.names) - 1}
+}
+
+// unwrapPins restores the pins wrapped by a recording, if any
+func (l *Device) unwrapPins() {
+	unwrap := func(p Pin) Pin {
+		if rp, ok := p.(*recPin); ok {
+			return rp.Pin
+		}
+		return p
+	}
+	l.pinRS = unwrap(l.pinRS)
+	l.pinE = unwrap(l.pinE)
+	if l.pinE2 != nil {
+		l.pinE2 = unwrap(l.pinE2)
+	}
+	if l.pinRW != nil {
+		l.pinRW = unwrap(l.pinRW)
+	}
+
+	for i, p := range l.pinDs {
+		l.pinDs[i] = unwrap(p)
+	}
+	l.rec = nil
+}
+
+// vcdID returns the short identifier of the signal used in the VCD format
+func vcdID(sig int) string {
+	return string(rune('!' + sig))
+}
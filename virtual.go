@@ -0,0 +1,100 @@
+package st7066u
+
+import "sync"
+
+// Virtual is a virtual display larger than the display, e.g. 40x4 on a 20x4 display, of which a
+// window the size of the display is shown, moved by Pan. Use func NewVirtual to get a new struct
+type Virtual struct {
+	l    *Device
+	mu   sync.Mutex
+	f    *Frame // Content of the virtual display
+	x, y int    // Top left of the window shown
+}
+
+// NewVirtual returns a Virtual display of the rows and cols, at least as many as the display has,
+// cleared and with the window at its top left. Nothing is drawn until it is updated or panned. The
+// window is drawn by writing the characters that changed when panning, as the display shift of the
+// controller doesn't move rows that continue lines of the controller, e.g. on a 20x4 display
+func (l *Device) NewVirtual(rows, cols uint8) *Virtual {
+	if rows < l.rows {
+		rows = l.rows
+	}
+	if cols < l.cols {
+		cols = l.cols
+	}
+	f := &Frame{rows: rows, cols: cols, cells: make([][]byte, rows), l: l}
+	for r := range f.cells {
+		f.cells[r] = make([]byte, cols)
+	}
+	f.Clear()
+	return &Virtual{l: l, f: f}
+}
+
+// Update stages the changes made by fn to the Frame of the virtual display, and then shows the
+// window, writing only the characters that changed, as Device.Update does. The cursor of the frame
+// is shown if within the window. fn is run while holding the device, so it must not call any methods
+// of the Device
+func (v *Virtual) Update(fn func(f *Frame)) {
+	v.l.Update(func(f *Frame) {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		fn(v.f)
+		v.show(f)
+	})
+}
+
+// Pan moves the window by dx columns and dy rows, within the virtual display, and shows it
+func (v *Virtual) Pan(dx, dy int) {
+	v.l.Update(func(f *Frame) {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		v.moveTo(v.y+dy, v.x+dx)
+		v.show(f)
+	})
+}
+
+// PanTo moves the top left of the window to the row and col, within the virtual display, and shows
+// it
+func (v *Virtual) PanTo(row, col int) {
+	v.l.Update(func(f *Frame) {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		v.moveTo(row, col)
+		v.show(f)
+	})
+}
+
+// Window returns the row and col of the top left of the window
+func (v *Virtual) Window() (row, col int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.y, v.x
+}
+
+// moveTo moves the top left of the window to the row and col, within the virtual display
+func (v *Virtual) moveTo(row, col int) {
+	v.y = clampInt(row, 0, int(v.f.rows)-int(v.l.rows))
+	v.x = clampInt(col, 0, int(v.f.cols)-int(v.l.cols))
+}
+
+// show copies the window of the virtual display to the frame f of the display
+func (v *Virtual) show(f *Frame) {
+	for r := range f.cells {
+		copy(f.cells[r], v.f.cells[v.y+r][v.x:])
+	}
+	row, col := int(v.f.row)-v.y, int(v.f.col)-v.x
+	if row >= 0 && row < int(f.rows) && col >= 0 && col < int(f.cols) {
+		f.row, f.col = uint8(row), uint8(col)
+	}
+}
+
+// clampInt returns v within min and max
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
@@ -0,0 +1,66 @@
+package st7066u
+
+import "math"
+
+// vuFull is the ROM code of the fully filled character, used for full cells of a VU meter
+const vuFull = 0xff
+
+// VUMeter draws a vertical bar in the column, filling height rows up from the bottom row of the
+// display to the level, from 0.0 (empty) to 1.0 (full). Each cell is filled in 8 steps, with the
+// partially filled cell drawn by a user-defined character (registered as the block elements '▁' to
+// '▇', see RegisterGlyph), so several meters can be shown at once, e.g. for audio levels on a 4 row
+// display. Only the cells that changed are written
+func (l *Device) VUMeter(col uint8, height uint8, level float64) {
+	l.Update(func(f *Frame) { f.VUMeter(col, height, level) })
+}
+
+// VUMeter draws a vertical bar in the frame, as func VUMeter of the Device does
+func (f *Frame) VUMeter(col uint8, height uint8, level float64) {
+	if col >= f.cols {
+		return
+	}
+	if height > f.rows {
+		height = f.rows
+	}
+	if level < 0 {
+		level = 0
+	}
+	if level > 1 {
+		level = 1
+	}
+	f.eachDevice((*Device).registerVUGlyphs)
+	filled := int(math.Round(level * float64(height) * 8))
+	row, c := f.row, f.col
+	for i := 0; i < int(height); i++ {
+		n := filled - i*8
+		f.SetCursor(f.rows-1-uint8(i), col)
+		switch {
+		case n <= 0:
+			f.PrintByte(0x20)
+		case n >= 8:
+			f.PrintByte(vuFull)
+		default:
+			f.PrintRune('▁' + rune(n-1))
+		}
+	}
+	f.row, f.col = row, c
+}
+
+// registerVUGlyphs registers the glyphs of the partially filled cells of a VU meter, '▁' to '▇', if
+// not registered already
+func (l *Device) registerVUGlyphs() {
+	if l.glyphs == nil {
+		l.glyphs = make(map[rune]Glyph)
+	}
+	for n := 1; n < 8; n++ {
+		r := '▁' + rune(n-1)
+		if _, ok := l.glyphs[r]; ok {
+			continue
+		}
+		var g Glyph
+		for k := 0; k < n; k++ {
+			g[len(g)-1-k] = 0x1f
+		}
+		l.glyphs[r] = g
+	}
+}
@@ -0,0 +1,41 @@
+package st7066u
+
+import "time"
+
+// SetWatchdog turns the auto-refresh watchdog on or off. When on, the mode, display control and
+// entry mode instructions, and the content of the display, are written again every interval, to heal
+// a display corrupted by electrical noise on e.g. long cables. This is done without clearing the
+// display, so nothing is visible when the display is fine. An interval of 0 turns the watchdog off
+func (l *Device) SetWatchdog(interval time.Duration) {
+	l.mu.Lock()
+	stop, done := l.watchdog, l.watchdogDone
+	l.watchdog, l.watchdogDone = nil, nil
+	l.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	if interval <= 0 {
+		return
+	}
+	stop, done = make(chan struct{}), make(chan struct{})
+	l.mu.Lock()
+	l.watchdog, l.watchdogDone = stop, done
+	l.mu.Unlock()
+	go l.watch(interval, stop, done)
+}
+
+// watch refreshes the display every interval until stop is closed
+func (l *Device) watch(interval time.Duration, stop, done chan struct{}) {
+	defer close(done)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-t.C:
+			l.do(l.refresh)
+		}
+	}
+}
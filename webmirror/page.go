@@ -0,0 +1,68 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+package webmirror
+
+// page is the page drawing the mockup of the display, from the messages of the WebSocket
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>LCD</title>
+<style>
+body { margin: 0; padding: 24px; background: #222; color: #999; font: 13px sans-serif; }
+#lcd { padding: 14px; background: #111; border-radius: 6px; display: inline-block; }
+#status { margin-top: 8px; }
+</style>
+</head>
+<body>
+<div id="lcd"><canvas id="screen" width="0" height="0"></canvas></div>
+<div id="status">Connecting...</div>
+<script>
+"use strict";
+var DOT = 4, PITCH = 5, GAP = 4, BORDER = 10;
+var canvas = document.getElementById("screen"), ctx = canvas.getContext("2d");
+var status = document.getElementById("status");
+
+function shade(c, f) {
+	return "rgb(" + Math.round(c[0] * f) + "," + Math.round(c[1] * f) + "," + Math.round(c[2] * f) + ")";
+}
+
+function draw(m) {
+	var w = BORDER * 2 + m.cols * (m.width * PITCH + GAP) - GAP;
+	var h = BORDER * 2 + m.rows * (m.height * PITCH + GAP) - GAP;
+	if (canvas.width !== w || canvas.height !== h) {
+		canvas.width = w;
+		canvas.height = h;
+	}
+	var white = m.color[0] === 255 && m.color[1] === 255 && m.color[2] === 255;
+	var base = white ? [168, 198, 78] : m.color;
+	var light = m.backlight ? 1 : 0.45;
+	ctx.fillStyle = shade(base, light);
+	ctx.fillRect(0, 0, w, h);
+	var off = shade(base, light * 0.9), on = shade(base, 0.18);
+	for (var y = 0; y < m.dots.length; y++) {
+		var row = m.dots[y], top = BORDER + y * PITCH + Math.floor(y / m.height) * GAP;
+		for (var x = 0; x < row.length; x++) {
+			var left = BORDER + x * PITCH + Math.floor(x / m.width) * GAP;
+			ctx.fillStyle = row.charAt(x) === "1" ? on : off;
+			ctx.fillRect(left, top, DOT, DOT);
+		}
+	}
+}
+
+function connect() {
+	var ws = new WebSocket(new URL("ws", location.href).href.replace(/^http/, "ws"));
+	ws.onopen = function () { status.textContent = "Connected"; };
+	ws.onmessage = function (e) { draw(JSON.parse(e.data)); };
+	ws.onclose = function () {
+		status.textContent = "Disconnected, reconnecting...";
+		setTimeout(connect, 2000);
+	};
+}
+connect();
+</script>
+</body>
+</html>
+`
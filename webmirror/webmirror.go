@@ -0,0 +1,220 @@
+//go:build !st7066u_small
+// +build !st7066u_small
+
+// Package webmirror mirrors a Device to a web page, showing a mockup of the display that is
+// accurate to the dot, updated live over a WebSocket, e.g. to see what a headless device shows
+// when debugging it remotely. The endpoints are
+//
+//	GET /		The page
+//	GET /ws		The WebSocket, sending the dots shown as JSON each time they change
+//
+// The page and the WebSocket only read from the device, but there is no authentication, so only
+// serve them on trusted networks. The package needs the dot matrix of the device, so it isn't built
+// with the st7066u_small build tag
+package webmirror
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hossner/go-st7066u"
+)
+
+// Interval is how often the dots shown are checked for changes
+const Interval = time.Millisecond * 100
+
+// wsGUID is the GUID of the WebSocket protocol, see RFC 6455
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocket opcodes
+const (
+	opText  = 0x1
+	opClose = 0x8
+)
+
+// Handler returns a http.Handler serving the page and WebSocket for the device, e.g. to be mounted
+// on a ServeMux of the program with http.StripPrefix. Mount it on a path ending with a slash, e.g.
+// "/lcd/", as the page connects to the WebSocket relative to its own path
+func Handler(dev *st7066u.Device) http.Handler {
+	return &handler{dev: dev}
+}
+
+// ListenAndServe serves the page and WebSocket for the device on the TCP address, e.g. ":8067",
+// until it fails
+func ListenAndServe(addr string, dev *st7066u.Device) error {
+	return http.ListenAndServe(addr, Handler(dev))
+}
+
+// handler serves the page and WebSocket for a device
+type handler struct {
+	dev *st7066u.Device
+}
+
+// message is what is sent over the WebSocket
+type message struct {
+	Rows      uint8    `json:"rows"`
+	Cols      uint8    `json:"cols"`
+	Width     int      `json:"width"`
+	Height    int      `json:"height"`
+	Dots      []string `json:"dots"` // Rows of dots from the top, '1' for on and '0' for off
+	Backlight bool     `json:"backlight"`
+	Color     [3]uint8 `json:"color"`
+}
+
+// ServeHTTP implements http.Handler
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	if path != "" && path != "ws" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if path == "" {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, page)
+		return
+	}
+	h.serveSocket(w, r)
+}
+
+// serveSocket upgrades the request to a WebSocket, and sends the dots shown each time they change,
+// until the connection is closed
+func (h *handler) serveSocket(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || key == "" {
+		http.Error(w, "Expected a WebSocket handshake", http.StatusBadRequest)
+		return
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSockets not supported by the server", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	sum := sha1.Sum([]byte(key + wsGUID))
+	rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	rw.WriteString("Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n")
+	if rw.Flush() != nil {
+		return
+	}
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		readFrames(rw.Reader)
+	}()
+	ticker := time.NewTicker(Interval)
+	defer ticker.Stop()
+	var last []byte
+	for {
+		if b := h.encode(); !bytes.Equal(b, last) {
+			if writeFrame(conn, opText, b) != nil {
+				return
+			}
+			last = b
+		}
+		select {
+		case <-closed:
+			writeFrame(conn, opClose, nil)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// encode returns the JSON of what the device shows
+func (h *handler) encode() []byte {
+	m := h.dev.Dots()
+	c := h.dev.BacklightColor()
+	msg := message{
+		Rows:      m.Rows,
+		Cols:      m.Cols,
+		Width:     m.Width,
+		Height:    m.Height,
+		Dots:      make([]string, len(m.Dots)),
+		Backlight: m.Backlight,
+		Color:     [3]uint8{c.R, c.G, c.B},
+	}
+	for i, row := range m.Dots {
+		b := make([]byte, len(row))
+		for j, on := range row {
+			b[j] = '0'
+			if on {
+				b[j] = '1'
+			}
+		}
+		msg.Dots[i] = string(b)
+	}
+	b, _ := json.Marshal(msg)
+	return b
+}
+
+// readFrames reads and discards the frames sent by the client, until it closes the connection
+func readFrames(r *bufio.Reader) {
+	for {
+		var head [2]byte
+		if _, err := io.ReadFull(r, head[:]); err != nil {
+			return
+		}
+		if head[0]&0x0f == opClose {
+			return
+		}
+		n := int64(head[1] & 0x7f)
+		switch n {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(r, ext[:]); err != nil {
+				return
+			}
+			n = int64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(r, ext[:]); err != nil {
+				return
+			}
+			n = int64(binary.BigEndian.Uint64(ext[:]) & (1<<63 - 1))
+		}
+		if head[1]&0x80 != 0 {
+			n += 4 // Masking key
+		}
+		if _, err := io.CopyN(ioutil.Discard, r, n); err != nil {
+			return
+		}
+	}
+}
+
+// writeFrame writes an unfragmented frame of the opcode, with the payload
+func writeFrame(conn net.Conn, opcode byte, payload []byte) error {
+	frame := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, byte(n))
+	case n <= 0xffff:
+		frame = append(frame, 126, byte(n>>8), byte(n))
+	default:
+		frame = append(frame, 127)
+		for i := 7; i >= 0; i-- {
+			frame = append(frame, byte(uint64(n)>>(8*i)))
+		}
+	}
+	conn.SetWriteDeadline(time.Now().Add(time.Second * 10))
+	_, err := conn.Write(append(frame, payload...))
+	return err
+}